@@ -0,0 +1,370 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"bytes"
+	"math"
+	"sort"
+)
+
+// Changed determines whether cur differs from prev by more than the given threshold. A change
+// between a valid and the invalid value always counts, whereas two invalid values are unchanged.
+func Changed(prev, cur DPT_9001, threshold float32) bool {
+	if !prev.Valid() || !cur.Valid() {
+		return prev.Valid() != cur.Valid()
+	}
+
+	delta := float32(cur - prev)
+	if delta < 0 {
+		delta = -delta
+	}
+
+	return delta > threshold
+}
+
+// ShouldSend implements a send-on-delta policy with an absolute and a relative threshold. It
+// determines whether cur differs from prev by more than abs, or by more than the fraction rel of
+// prev (e.g. 0.05 for 5%). A threshold of zero or less disables the respective criterion, and the
+// relative criterion does not apply if prev is zero. Validity changes are handled like in Changed.
+func ShouldSend(prev, cur DPT_9001, abs, rel float32) bool {
+	if !prev.Valid() || !cur.Valid() {
+		return prev.Valid() != cur.Valid()
+	}
+
+	delta := math.Abs(float64(cur - prev))
+
+	if abs > 0 && delta > float64(abs) {
+		return true
+	}
+
+	if rel > 0 && prev != 0 && delta/math.Abs(float64(prev)) > float64(rel) {
+		return true
+	}
+
+	return false
+}
+
+// DewPoint computes the dew point for the given temperature and relative humidity using the Magnus
+// formula with the coefficients of Sonntag (17.62, 243.12 °C). The result is re-encoded through
+// the 2-octet float format. If either value is invalid or the humidity is zero, the result is
+// invalid.
+func DewPoint(temp DPT_9001, rh DPT_9007) DPT_9001 {
+	if !temp.Valid() || !rh.Valid() || rh <= 0 {
+		return DPT_9001(math.NaN())
+	}
+
+	const b, c = 17.62, 243.12
+
+	t := float64(temp)
+	gamma := math.Log(float64(rh)/100) + b*t/(c+t)
+
+	return DPT_9001(quantizeF16(float32(c * gamma / (b - gamma))))
+}
+
+// quantize returns the value as it is after a round trip through the given format.
+func quantize(f float32, pack func(float32) []byte, unpack func([]byte, *float32) error) float32 {
+	return unpackStable(pack(f), pack, unpack)
+}
+
+// unpackStable unpacks the data like unpack. The encoders truncate, so float rounding may make an
+// unpacked value pack to smaller octets than it came from. In that case the nearest float32
+// further from zero which packs to the same octets is returned instead, so that quantizing a
+// quantized value does not change it.
+func unpackStable(data []byte, pack func(float32) []byte, unpack func([]byte, *float32) error) float32 {
+	var value float32
+	if err := unpack(data, &value); err != nil || value != value {
+		return value
+	}
+
+	limit := float32(math.Inf(1))
+	if value < 0 {
+		limit = -limit
+	}
+
+	for q, i := value, 0; i < 8; i++ {
+		if bytes.Equal(pack(q), data) {
+			return q
+		}
+
+		q = math.Nextafter32(q, limit)
+	}
+
+	return value
+}
+
+// quantizeF16 returns the value as it is after a round trip through the 2-octet float format.
+func quantizeF16(f float32) float32 {
+	return quantize(f, packF16, unpackF16)
+}
+
+// roundF16 returns the 2-octet float value nearest to f. Unlike packF16, which truncates the
+// mantissa, it rounds it. The greatest mantissa with the greatest exponent is the invalid value
+// marker 0x7FFF, so values which would round to it yield the greatest valid value instead.
+func roundF16(f float32) float32 {
+	if f != f {
+		return f
+	}
+
+	scaled := float64(f) * 100
+	exp := 0
+
+	for scaled >= 2047.5 || scaled < -2048.5 {
+		scaled /= 2
+		exp++
+	}
+
+	mantissa := int(math.Floor(scaled + 0.5))
+
+	if exp > 15 {
+		return quantizeF16(f)
+	} else if exp == 15 && mantissa > 2046 {
+		mantissa = 2046
+	}
+
+	return unpackStable(encodeF16(nil, mantissa, exp), packF16, unpackF16)
+}
+
+// A Smoother computes the exponential moving average of DPT_9001 readings. Estimates are
+// re-encoded through the 2-octet float format, so they never carry more precision than can be
+// transmitted.
+type Smoother struct {
+	// Alpha is the weight of a new reading in the range (0, 1]. Greater values make the average
+	// follow changes more quickly.
+	Alpha float32
+
+	value  float32
+	primed bool
+}
+
+// Add feeds a reading into the smoother and returns the new smoothed value. The first reading
+// initializes the average. An invalid reading is propagated as it is and does not affect the
+// average.
+func (s *Smoother) Add(v DPT_9001) DPT_9001 {
+	if !v.Valid() {
+		return v
+	}
+
+	if !s.primed {
+		s.value = quantizeF16(float32(v))
+		s.primed = true
+	} else {
+		s.value = quantizeF16(s.Alpha*float32(v) + (1-s.Alpha)*s.value)
+	}
+
+	return DPT_9001(s.value)
+}
+
+// A KalmanFilter estimates a slowly changing temperature from noisy DPT_9001 readings using a
+// one-dimensional Kalman filter. The filter keeps its state in full precision, but estimates are
+// re-encoded through the 2-octet float format.
+type KalmanFilter struct {
+	// ProcessNoise is the variance by which the true temperature is expected to change between
+	// two readings. Greater values make the estimate follow changes more quickly.
+	ProcessNoise float64
+
+	// MeasurementNoise is the variance of the readings. Greater values smooth more strongly.
+	MeasurementNoise float64
+
+	estimate float64
+	variance float64
+	primed   bool
+}
+
+// Add feeds a reading into the filter and returns the new estimate. The first reading initializes
+// the estimate. An invalid reading is propagated as it is and does not affect the estimate.
+func (k *KalmanFilter) Add(v DPT_9001) DPT_9001 {
+	if !v.Valid() {
+		return v
+	}
+
+	if !k.primed {
+		k.estimate = float64(v)
+		k.variance = k.MeasurementNoise
+		k.primed = true
+	} else {
+		variance := k.variance + k.ProcessNoise
+		gain := 1.0
+		if variance+k.MeasurementNoise > 0 {
+			gain = variance / (variance + k.MeasurementNoise)
+		}
+
+		k.estimate += gain * (float64(v) - k.estimate)
+		k.variance = (1 - gain) * variance
+	}
+
+	return DPT_9001(quantizeF16(float32(k.estimate)))
+}
+
+// Stats computes the minimum, maximum and mean of the given temperatures. The results are
+// re-encoded through the 2-octet float format so they can be transmitted as they are. Invalid
+// values are skipped. If no valid values remain, Stats yields zero values.
+func Stats(values []DPT_9001) (min, max, mean DPT_9001) {
+	var count int
+	var sum float64
+
+	for _, value := range values {
+		if !value.Valid() {
+			continue
+		}
+
+		if count == 0 {
+			min, max = value, value
+		}
+		count++
+
+		if value < min {
+			min = value
+		}
+		if value > max {
+			max = value
+		}
+
+		sum += float64(value)
+	}
+
+	if count == 0 {
+		return
+	}
+
+	min = DPT_9001(quantizeF16(float32(min)))
+	max = DPT_9001(quantizeF16(float32(max)))
+	mean = DPT_9001(quantizeF16(float32(sum / float64(count))))
+
+	return
+}
+
+// RobustMean computes the mean of the given temperatures after rejecting outliers, e.g. spikes of
+// a faulty sensor. Values which deviate from the median by more than three times the scaled median
+// absolute deviation are rejected. If more than half of the values are equal, every other value
+// counts as outlier. The result is re-encoded through the 2-octet float format. Invalid values are
+// skipped. If no valid values remain, RobustMean yields zero like Stats.
+func RobustMean(values []DPT_9001) DPT_9001 {
+	valid := make([]float64, 0, len(values))
+	for _, value := range values {
+		if value.Valid() {
+			valid = append(valid, float64(value))
+		}
+	}
+
+	if len(valid) == 0 {
+		return 0
+	}
+
+	median := medianOf(valid)
+
+	deviations := make([]float64, len(valid))
+	for i, value := range valid {
+		deviations[i] = math.Abs(value - median)
+	}
+
+	// 1.4826 scales the median absolute deviation to the standard deviation of normal data.
+	limit := 3 * 1.4826 * medianOf(deviations)
+
+	var count int
+	var sum float64
+
+	for i, value := range valid {
+		if deviations[i] <= limit {
+			sum += value
+			count++
+		}
+	}
+
+	return DPT_9001(quantizeF16(float32(sum / float64(count))))
+}
+
+// medianOf computes the median of the given values.
+func medianOf(values []float64) float64 {
+	values = append([]float64(nil), values...)
+	sort.Float64s(values)
+
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+
+	return (values[n/2-1] + values[n/2]) / 2
+}
+
+// A Ditherer packs scaled values (DPT 5.001 and 5.003) with error diffusion. The quantization
+// error of each packed value is carried over to the next one, so the average of a series of
+// packed values tracks the average of the original values instead of sticking to a single octet.
+// This reduces banding when slowly changing values are sent repeatedly. The zero value is ready
+// to use. Use one Ditherer per group address.
+type Ditherer struct {
+	residual float64
+}
+
+// PackScaling packs a DPT_5001 value with error diffusion.
+func (d *Ditherer) PackScaling(v DPT_5001) []byte {
+	return d.packScaled(float32(v), 100)
+}
+
+// PackAngle packs a DPT_5003 value with error diffusion.
+func (d *Ditherer) PackAngle(v DPT_5003) []byte {
+	angle := math.Mod(float64(v), 360)
+	if angle < 0 {
+		angle += 360
+	}
+
+	return d.packScaled(float32(angle), 360)
+}
+
+func (d *Ditherer) packScaled(value, max float32) []byte {
+	if value < 0 {
+		value = 0
+	} else if value > max {
+		value = max
+	}
+
+	exact := float64(value)*255/float64(max) + d.residual
+
+	octet := math.Floor(exact + 0.5)
+	if octet < 0 {
+		octet = 0
+	} else if octet > 255 {
+		octet = 255
+	}
+
+	d.residual = exact - octet
+
+	return packU8(uint8(octet))
+}
+
+// A BrightnessPoint is a point of an AutoBrightness curve.
+type BrightnessPoint struct {
+	Lux   DPT_9004
+	Level DPT_5001
+}
+
+// AutoBrightness maps measured illuminance onto a target brightness for daylight-adaptive lighting.
+type AutoBrightness struct {
+	// Curve holds the points of the curve in ascending order of illuminance. Between the points,
+	// the brightness is interpolated linearly. Below the first and above the last point, the
+	// brightness of that point applies.
+	Curve []BrightnessPoint
+}
+
+// Brightness returns the target brightness for the given illuminance. The second result is false
+// if the illuminance is invalid or the curve is empty.
+func (a AutoBrightness) Brightness(lux DPT_9004) (DPT_5001, bool) {
+	if !lux.Valid() || len(a.Curve) == 0 {
+		return 0, false
+	}
+
+	if lux <= a.Curve[0].Lux {
+		return a.Curve[0].Level, true
+	}
+
+	for i := 1; i < len(a.Curve); i++ {
+		lower, upper := a.Curve[i-1], a.Curve[i]
+		if lux < upper.Lux {
+			t := float32(lux-lower.Lux) / float32(upper.Lux-lower.Lux)
+			return lower.Level + DPT_5001(t)*(upper.Level-lower.Level), true
+		}
+	}
+
+	return a.Curve[len(a.Curve)-1].Level, true
+}
@@ -0,0 +1,310 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var invalidTemp = DPT_9001(math.NaN())
+
+func TestChanged(t *testing.T) {
+	cases := []struct {
+		prev, cur DPT_9001
+		threshold float32
+		changed   bool
+	}{
+		{21, 21, 0.5, false},
+		{21, 21.25, 0.5, false},
+		{21, 21.5, 0.5, false},
+		{21, 20.5, 0.5, false},
+		{21, 21.75, 0.5, true},
+		{21, 20.25, 0.5, true},
+		{21, 21.25, 0, true},
+	}
+
+	for _, c := range cases {
+		if changed := Changed(c.prev, c.cur, c.threshold); changed != c.changed {
+			t.Errorf("Changed(%v, %v, %v) = %v, expected %v", c.prev, c.cur, c.threshold, changed, c.changed)
+		}
+	}
+}
+
+func TestShouldSend(t *testing.T) {
+	cases := []struct {
+		prev, cur DPT_9001
+		abs, rel  float32
+		send      bool
+	}{
+		{20, 20.25, 0.5, 0.05, false},
+		{20, 20.75, 0.5, 0.05, true}, // Absolute threshold only.
+		{2, 2.25, 0.5, 0.1, true},    // Relative threshold only.
+		{-2, -2.25, 0.5, 0.1, true},  // Relative to a negative value.
+		{2, 2.25, 0.5, 0, false},     // Relative criterion disabled.
+		{20, 21, 0, 0.1, false},      // Absolute criterion disabled.
+		{0, 0.25, 0.5, 0.1, false},   // No relative change from zero.
+		{20, 20, 0, 0, false},
+		{20, invalidTemp, 0.5, 0.1, true},
+		{invalidTemp, invalidTemp, 0.5, 0.1, false},
+	}
+
+	for _, c := range cases {
+		if send := ShouldSend(c.prev, c.cur, c.abs, c.rel); send != c.send {
+			t.Errorf("ShouldSend(%v, %v, %v, %v) = %v, expected %v", c.prev, c.cur, c.abs, c.rel, send, c.send)
+		}
+	}
+}
+
+func TestDewPoint(t *testing.T) {
+	cases := []struct {
+		temp     DPT_9001
+		rh       DPT_9007
+		expected float32
+	}{
+		{20, 50, 9.26},
+		{25, 60, 16.69},
+		{30, 90, 28.18},
+		{0, 80, -3.04},
+		{20, 100, 20},
+	}
+
+	for _, c := range cases {
+		if dp := DewPoint(c.temp, c.rh); abs(float32(dp)-c.expected) > 0.05 {
+			t.Errorf("DewPoint(%v, %v) = %v, expected %v", c.temp, c.rh, dp, c.expected)
+		}
+	}
+
+	if dp := DewPoint(invalidTemp, 50); dp.Valid() {
+		t.Errorf("DewPoint of the invalid temperature is %v", dp)
+	}
+	if dp := DewPoint(20, DPT_9007(math.NaN())); dp.Valid() {
+		t.Errorf("DewPoint of the invalid humidity is %v", dp)
+	}
+	if dp := DewPoint(20, 0); dp.Valid() {
+		t.Errorf("DewPoint at 0%% humidity is %v", dp)
+	}
+}
+
+func TestSmoother(t *testing.T) {
+	s := Smoother{Alpha: 0.5}
+
+	if v := s.Add(0); v != 0 {
+		t.Errorf("First reading yields \"%s\", expected 0", v)
+	}
+
+	// Feed a step input and check that the average converges towards it.
+	prev := DPT_9001(0)
+	for i := 0; i < 20; i++ {
+		v := s.Add(20)
+		if v < prev {
+			t.Errorf("Smoothed value decreased from \"%s\" to \"%s\" on a rising step", prev, v)
+		}
+		prev = v
+	}
+
+	if abs(float32(prev)-20) > 0.05 {
+		t.Errorf("Smoothed value \"%s\" did not converge to 20", prev)
+	}
+
+	if v := s.Add(20); v != DPT_9001(quantizeF16(float32(v))) {
+		t.Errorf("Smoothed value \"%s\" is not representable as 2-octet float", v)
+	}
+
+	// After one reading the average must be half way between both values.
+	s = Smoother{Alpha: 0.5}
+	s.Add(10)
+	if v := s.Add(20); abs(float32(v)-15) > epsilon {
+		t.Errorf("Average of 10 and 20 with alpha 0.5 is \"%s\", expected 15", v)
+	}
+}
+
+func TestKalmanFilter(t *testing.T) {
+	k := KalmanFilter{ProcessNoise: 0.001, MeasurementNoise: 0.25}
+
+	if v := k.Add(20); v != 20 {
+		t.Errorf("First reading yields \"%s\", expected 20", v)
+	}
+
+	// Feed a step input and check that the estimate converges towards it.
+	var v DPT_9001
+	for i := 0; i < 200; i++ {
+		v = k.Add(25)
+	}
+
+	if abs(float32(v)-25) > 0.05 {
+		t.Errorf("Estimate \"%s\" did not converge to 25", v)
+	}
+
+	if v != DPT_9001(quantizeF16(float32(v))) {
+		t.Errorf("Estimate \"%s\" is not representable as 2-octet float", v)
+	}
+
+	// Feed a constant signal with noise and compare the errors of readings and estimates.
+	k = KalmanFilter{ProcessNoise: 0.001, MeasurementNoise: 0.25}
+	noise := rand.New(rand.NewSource(1))
+
+	var rawError, filteredError float64
+	for i := 0; i < 500; i++ {
+		reading := DPT_9001(21 + noise.NormFloat64()*0.5)
+		estimate := k.Add(reading)
+
+		// Skip the initial phase in which the filter settles.
+		if i >= 50 {
+			rawError += math.Pow(float64(reading)-21, 2)
+			filteredError += math.Pow(float64(estimate)-21, 2)
+		}
+	}
+
+	if filteredError > rawError/10 {
+		t.Errorf("Squared error of estimates is %v, readings have %v", filteredError, rawError)
+	}
+
+	// Invalid readings are propagated without disturbing the estimate.
+	other := k
+	if v := k.Add(invalidTemp); v.Valid() {
+		t.Errorf("KalmanFilter turned an invalid reading into \"%s\"", v)
+	}
+	if v, expected := k.Add(22), other.Add(22); v != expected {
+		t.Errorf("KalmanFilter yields \"%s\" after an invalid reading, expected \"%s\"", v, expected)
+	}
+}
+
+func TestStats(t *testing.T) {
+	min, max, mean := Stats([]DPT_9001{21.5, 19, 22.5, 21})
+	if min != 19 || max != 22.5 || abs(float32(mean)-21) > epsilon {
+		t.Errorf("Stats yields (%v, %v, %v), expected (19, 22.5, 21)", min, max, mean)
+	}
+
+	// The mean 1/3 is not representable and has to be re-encoded.
+	_, _, mean = Stats([]DPT_9001{0, 0, 1})
+	if float32(mean) != quantizeF16(float32(mean)) || abs(float32(mean)-0.33) > epsilon {
+		t.Errorf("Mean of 0, 0 and 1 is \"%s\", expected 0.33", mean)
+	}
+
+	min, max, mean = Stats(nil)
+	if min != 0 || max != 0 || mean != 0 {
+		t.Errorf("Stats of an empty slice yields (%v, %v, %v), expected zero values", min, max, mean)
+	}
+}
+
+func TestAnalogInvalid(t *testing.T) {
+	// Transitions between valid and invalid values are changes, invalid to invalid is not.
+	if !Changed(21, invalidTemp, 100) || !Changed(invalidTemp, 21, 100) {
+		t.Errorf("Change between valid and invalid value is not detected")
+	}
+	if Changed(invalidTemp, invalidTemp, 0) {
+		t.Errorf("Two invalid values are reported as changed")
+	}
+
+	// Stats skips invalid values.
+	min, max, mean := Stats([]DPT_9001{invalidTemp, 20, invalidTemp, 22})
+	if min != 20 || max != 22 || mean != 21 {
+		t.Errorf("Stats with invalid values yields (%v, %v, %v), expected (20, 22, 21)", min, max, mean)
+	}
+	if min, max, mean := Stats([]DPT_9001{invalidTemp}); min != 0 || max != 0 || mean != 0 {
+		t.Errorf("Stats of only invalid values yields (%v, %v, %v), expected zero values", min, max, mean)
+	}
+
+	// Smoother propagates invalid values without disturbing the average.
+	s := Smoother{Alpha: 0.5}
+	s.Add(20)
+	if v := s.Add(invalidTemp); v.Valid() {
+		t.Errorf("Smoother turned an invalid reading into \"%s\"", v)
+	}
+	if v := s.Add(20); v != 20 {
+		t.Errorf("Smoother yields \"%s\" after an invalid reading, expected 20", v)
+	}
+}
+
+func TestDitherer(t *testing.T) {
+	var d Ditherer
+	var sum float64
+
+	const n = 1000
+	src := DPT_5001(0.2)
+
+	for i := 0; i < n; i++ {
+		var dst DPT_5001
+		dst.Unpack(d.PackScaling(src))
+		sum += float64(dst)
+	}
+
+	if mean := sum / n; math.Abs(mean-0.2) > 0.01 {
+		t.Errorf("Mean of dithered values is %v, expected 0.2", mean)
+	}
+
+	var plain DPT_5001
+	plain.Unpack(src.Pack())
+	if plain != 0 {
+		t.Errorf("Plain packing of %v yields %v, expected 0", src, plain)
+	}
+
+}
+
+func TestRobustMean(t *testing.T) {
+	values := []DPT_9001{21, 21.5, 22, 21.5, 85, 21}
+	if mean := RobustMean(values); mean != 21.4 {
+		t.Errorf("RobustMean(%v) = %v, expected 21.40", values, mean)
+	}
+
+	_, _, plain := Stats(values)
+	if plain == 21.4 {
+		t.Errorf("Stats does not include the outlier")
+	}
+
+	values = []DPT_9001{-40, 20, 20.5, invalidTemp, 21, 20.5}
+	if mean := RobustMean(values); mean != 20.5 {
+		t.Errorf("RobustMean(%v) = %v, expected 20.50", values, mean)
+	}
+
+	values = []DPT_9001{22}
+	if mean := RobustMean(values); mean != 22 {
+		t.Errorf("RobustMean(%v) = %v, expected 22.00", values, mean)
+	}
+
+	if mean := RobustMean([]DPT_9001{invalidTemp}); mean != 0 {
+		t.Errorf("RobustMean of invalid values is %v, expected 0", mean)
+	}
+	if mean := RobustMean(nil); mean != 0 {
+		t.Errorf("RobustMean of no values is %v, expected 0", mean)
+	}
+}
+
+func TestAutoBrightness(t *testing.T) {
+	a := AutoBrightness{
+		Curve: []BrightnessPoint{
+			{Lux: 10, Level: 100},
+			{Lux: 200, Level: 60},
+			{Lux: 1000, Level: 0},
+		},
+	}
+
+	cases := []struct {
+		lux      DPT_9004
+		expected DPT_5001
+	}{
+		{0, 100}, // Dark
+		{10, 100},
+		{105, 80},
+		{200, 60},
+		{600, 30},
+		{1000, 0},
+		{50000, 0}, // Bright daylight
+	}
+
+	for _, c := range cases {
+		if level, ok := a.Brightness(c.lux); !ok || abs(float32(level-c.expected)) > epsilon {
+			t.Errorf("Brightness(%v) = (%v, %v), expected %v", c.lux, level, ok, c.expected)
+		}
+	}
+
+	if _, ok := a.Brightness(DPT_9004(math.NaN())); ok {
+		t.Errorf("Brightness accepts the invalid value")
+	}
+	if _, ok := (AutoBrightness{}).Brightness(100); ok {
+		t.Errorf("Brightness without curve succeeds")
+	}
+}
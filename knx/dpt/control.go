@@ -0,0 +1,442 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"math"
+	"time"
+)
+
+// A Debouncer suppresses flapping of DPT_1001 inputs. A new value is only reported once it has
+// been stable for the configured duration.
+type Debouncer struct {
+	// Window is the duration for which a new value must be stable before it is reported.
+	Window time.Duration
+
+	stable    DPT_1001
+	candidate DPT_1001
+	since     time.Time
+	pending   bool
+	primed    bool
+}
+
+// Update feeds a value observed at the given time into the debouncer. It returns the current
+// stable value and whether it has changed with this update. The first update establishes the
+// stable value without reporting a change.
+func (d *Debouncer) Update(v DPT_1001, now time.Time) (stable DPT_1001, changed bool) {
+	if !d.primed {
+		d.stable = v
+		d.primed = true
+		return d.stable, false
+	}
+
+	if v == d.stable {
+		d.pending = false
+		return d.stable, false
+	}
+
+	if !d.pending || v != d.candidate {
+		d.candidate = v
+		d.since = now
+		d.pending = true
+	}
+
+	if now.Sub(d.since) >= d.Window {
+		d.stable = d.candidate
+		d.pending = false
+		return d.stable, true
+	}
+
+	return d.stable, false
+}
+
+// A RateLimiter decides whether DPT_9001 updates for a single group address may be sent, to
+// protect the bus from sensors that report too often. Use one RateLimiter per address.
+type RateLimiter struct {
+	// Interval is the minimum time between two sent values.
+	Interval time.Duration
+
+	// Threshold is the change (see Changed) which forces a value to be sent within the interval.
+	Threshold float32
+
+	last   DPT_9001
+	sentAt time.Time
+	primed bool
+}
+
+// Allow reports whether the value observed at the given time should be sent. This is the case for
+// the first value, once the interval has passed since the last sent value, and for values that
+// changed by more than the threshold since the last sent value. Allowed values become the new
+// reference for later decisions.
+func (r *RateLimiter) Allow(v DPT_9001, now time.Time) bool {
+	if r.primed && now.Sub(r.sentAt) < r.Interval && !Changed(r.last, v, r.Threshold) {
+		return false
+	}
+
+	r.last = v
+	r.sentAt = now
+	r.primed = true
+
+	return true
+}
+
+// A SlewLimiter ramps a DPT_5001 value towards a target at a limited rate, to avoid abrupt jumps
+// when dimming.
+type SlewLimiter struct {
+	// Rate is the maximum change in percent per second.
+	Rate float32
+
+	// Value is the current output value. Set it to start ramping from a value other than 0%.
+	Value DPT_5001
+
+	last   time.Time
+	primed bool
+}
+
+// Step moves the current value towards the target as far as the rate permits for the time passed
+// since the previous step, and returns the new value. The first step only establishes the
+// reference time and leaves the value unchanged. If now is earlier than the previous step, no time
+// is considered to have passed.
+func (s *SlewLimiter) Step(target DPT_5001, now time.Time) DPT_5001 {
+	if !s.primed {
+		s.last = now
+		s.primed = true
+		return s.Value
+	}
+
+	// A clock that goes backwards must not let the value move by a negative rate.
+	elapsed := now.Sub(s.last)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	maxDelta := DPT_5001(float64(s.Rate) * elapsed.Seconds())
+	s.last = now
+
+	if target > s.Value+maxDelta {
+		s.Value += maxDelta
+	} else if target < s.Value-maxDelta {
+		s.Value -= maxDelta
+	} else {
+		s.Value = target
+	}
+
+	return s.Value
+}
+
+// A DimController implements the common dim button pattern on top of DPT_3007: pressing the button
+// starts dimming in one direction, releasing it stops dimming.
+type DimController struct {
+	pressed  bool
+	increase bool
+}
+
+// Press starts dimming in the given direction. It returns the telegram to send, which covers the
+// full range so the actuator dims until it is stopped. The second result is false if dimming in
+// this direction is already in progress and nothing needs to be sent.
+func (c *DimController) Press(increase bool) (DPT_3007, bool) {
+	if c.pressed && c.increase == increase {
+		return DPT_3007{}, false
+	}
+
+	c.pressed = true
+	c.increase = increase
+
+	return DPT_3007{Increase: increase, Value: 1}, true
+}
+
+// Release stops dimming. It returns the stop telegram to send. The second result is false if
+// dimming is not in progress and nothing needs to be sent.
+func (c *DimController) Release() (DPT_3007, bool) {
+	if !c.pressed {
+		return DPT_3007{}, false
+	}
+
+	c.pressed = false
+
+	return DPT_3007{Increase: c.increase, Value: 0}, true
+}
+
+// MergeMode selects how MergeBool combines the values of several sources.
+type MergeMode int
+
+const (
+	// MergeOr yields On if any value is On.
+	MergeOr MergeMode = iota
+
+	// MergeAnd yields On if all values are On.
+	MergeAnd
+
+	// MergeLast yields the last value.
+	MergeLast
+)
+
+// MergeBool combines the values written to the same 1-bit group object by several sources, in the
+// order in which they were written. Without values, MergeBool yields Off.
+func MergeBool(mode MergeMode, values ...DPT_1001) DPT_1001 {
+	if len(values) == 0 {
+		return DPT_1001_Off
+	}
+
+	switch mode {
+	case MergeAnd:
+		for _, v := range values {
+			if !v {
+				return DPT_1001_Off
+			}
+		}
+
+		return DPT_1001_On
+
+	case MergeLast:
+		return values[len(values)-1]
+
+	default:
+		for _, v := range values {
+			if v {
+				return DPT_1001_On
+			}
+		}
+
+		return DPT_1001_Off
+	}
+}
+
+// EdgeKind describes the transition between two successive 1-bit values.
+type EdgeKind int
+
+const (
+	// EdgeNone means the value did not change.
+	EdgeNone EdgeKind = iota
+
+	// EdgeRising means the value changed from Off to On.
+	EdgeRising
+
+	// EdgeFalling means the value changed from On to Off.
+	EdgeFalling
+)
+
+// Edge detects the transition from the previous to the current value.
+func Edge(prev, cur DPT_1001) EdgeKind {
+	if prev == cur {
+		return EdgeNone
+	} else if cur {
+		return EdgeRising
+	} else {
+		return EdgeFalling
+	}
+}
+
+// A PID controller computes a DPT_5001 control value, e.g. for a heating valve, from a DPT_9001
+// setpoint and measured temperature.
+type PID struct {
+	// Kp, Ki and Kd are the proportional, integral and derivative gains. The error is measured in
+	// Kelvin and time in seconds, so e.g. Kp = 10 opens the valve by 10% per Kelvin of error.
+	Kp, Ki, Kd float32
+
+	integral  float64
+	prevError float64
+	output    DPT_5001
+	last      time.Time
+	primed    bool
+}
+
+// Update feeds the setpoint and measured temperature at the given time into the controller and
+// returns the new control value in the range [0%, 100%]. The integral term is limited to the same
+// range, so it does not wind up while the output is saturated. If either temperature is invalid,
+// the previous control value is returned and the controller state is left as it is.
+func (p *PID) Update(setpoint, measured DPT_9001, now time.Time) DPT_5001 {
+	if !setpoint.Valid() || !measured.Valid() {
+		return p.output
+	}
+
+	err := float64(setpoint - measured)
+
+	var dt, derivative float64
+	if p.primed {
+		dt = now.Sub(p.last).Seconds()
+		if dt > 0 {
+			derivative = (err - p.prevError) / dt
+		}
+	}
+
+	p.last = now
+	p.prevError = err
+	p.primed = true
+
+	if p.Ki > 0 {
+		p.integral += err * dt
+
+		if limit := 100 / float64(p.Ki); p.integral > limit {
+			p.integral = limit
+		} else if p.integral < 0 {
+			p.integral = 0
+		}
+	}
+
+	output := float64(p.Kp)*err + float64(p.Ki)*p.integral + float64(p.Kd)*derivative
+	if output < 0 {
+		output = 0
+	} else if output > 100 {
+		output = 100
+	}
+
+	p.output = DPT_5001(output)
+
+	return p.output
+}
+
+// A ButtonAction describes what pressing a keypad button does: either write a 1-bit value or
+// activate a scene.
+type ButtonAction struct {
+	// Scene selects scene activation instead of a 1-bit write.
+	Scene bool
+
+	// Switch is the value written if Scene is not set.
+	Switch DPT_1001
+
+	// Number is the scene number (0 to 63) activated if Scene is set.
+	Number uint8
+}
+
+// Telegram returns the value to send for the action, a *DPT_1001 or a *DPT_18001.
+func (a ButtonAction) Telegram() DatapointValue {
+	if a.Scene {
+		return &DPT_18001{Scene: a.Number}
+	}
+
+	value := a.Switch
+	return &value
+}
+
+// ButtonMapping maps keypad buttons to their actions.
+type ButtonMapping map[int]ButtonAction
+
+// Press returns the value to send when the given button is pressed. The second result is false if
+// the button is not mapped.
+func (m ButtonMapping) Press(button int) (DatapointValue, bool) {
+	action, ok := m[button]
+	if !ok {
+		return nil, false
+	}
+
+	return action.Telegram(), true
+}
+
+// A PositionEstimator estimates the position of blinds from the DPT_3008 telegrams that move them,
+// for blinds which do not report their position. Positions follow the usual convention of 0% for
+// fully up (open) and 100% for fully down (closed). The estimate starts at 0%; set it through
+// Reset if the blinds start elsewhere.
+type PositionEstimator struct {
+	// TravelTime is the time the blinds take to travel from fully up to fully down.
+	TravelTime time.Duration
+
+	position float64
+	target   float64
+	moving   bool
+	since    time.Time
+}
+
+// Reset sets the estimated position, e.g. after the blinds reported their position or moved to
+// an end position.
+func (e *PositionEstimator) Reset(position DPT_5001) {
+	e.position = float64(position)
+	e.moving = false
+}
+
+// Apply updates the estimate with a telegram sent at the given time. Step code 1 moves the blinds
+// until they reach the end position or are stopped, step code 0 stops them and other step codes
+// move them by the corresponding fraction of the full range.
+func (e *PositionEstimator) Apply(cmd DPT_3008, now time.Time) {
+	e.position = e.positionAt(now)
+	e.since = now
+
+	if cmd.Value == 0 {
+		e.moving = false
+		return
+	}
+
+	step := 100 / float64(uint(1)<<(cmd.Value-1))
+	if !cmd.Down {
+		step = -step
+	}
+
+	e.target = math.Max(0, math.Min(100, e.position+step))
+	e.moving = true
+}
+
+// Position returns the estimated position as of the last telegram.
+func (e *PositionEstimator) Position() DPT_5001 {
+	return DPT_5001(e.position)
+}
+
+// PositionAt returns the estimated position at the given time, which accounts for blinds that are
+// still moving.
+func (e *PositionEstimator) PositionAt(now time.Time) DPT_5001 {
+	return DPT_5001(e.positionAt(now))
+}
+
+func (e *PositionEstimator) positionAt(now time.Time) float64 {
+	if !e.moving {
+		return e.position
+	}
+
+	if e.TravelTime <= 0 {
+		return e.target
+	}
+
+	travelled := float64(now.Sub(e.since)) / float64(e.TravelTime) * 100
+	if e.target > e.position {
+		return math.Min(e.target, e.position+travelled)
+	}
+
+	return math.Max(e.target, e.position-travelled)
+}
+
+// A PresenceHold derives occupancy from the DPT_1001 motion signal of a presence sensor. Occupancy
+// starts with motion and lasts until no motion has been seen for the hold time.
+type PresenceHold struct {
+	// Hold is the time for which occupancy lasts after the last motion.
+	Hold time.Duration
+
+	lastMotion time.Time
+	occupied   bool
+}
+
+// Update feeds the motion signal observed at the given time into the helper and returns whether
+// the area is occupied.
+func (p *PresenceHold) Update(motion DPT_1001, now time.Time) DPT_1001 {
+	if motion {
+		p.lastMotion = now
+		p.occupied = true
+	} else if p.occupied && now.Sub(p.lastMotion) >= p.Hold {
+		p.occupied = false
+	}
+
+	return DPT_1001(p.occupied)
+}
+
+// A Thermostat switches a heating on and off to keep a DPT_9001 temperature at the setpoint. The
+// deadband around the setpoint prevents chatter: heating turns on below setpoint - deadband/2 and
+// off above setpoint + deadband/2. Within the deadband, the output is kept.
+type Thermostat struct {
+	Setpoint DPT_9001
+	Deadband float32
+
+	heating bool
+}
+
+// Update feeds a temperature reading into the thermostat and returns whether to heat. An invalid
+// reading turns the heating off.
+func (th *Thermostat) Update(temp DPT_9001) DPT_1001 {
+	if !temp.Valid() {
+		th.heating = false
+	} else if float32(temp) < float32(th.Setpoint)-th.Deadband/2 {
+		th.heating = true
+	} else if float32(temp) > float32(th.Setpoint)+th.Deadband/2 {
+		th.heating = false
+	}
+
+	return DPT_1001(th.heating)
+}
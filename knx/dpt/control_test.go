@@ -0,0 +1,382 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDebouncer(t *testing.T) {
+	start := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	d := Debouncer{Window: 500 * time.Millisecond}
+
+	if v, changed := d.Update(false, at(0)); v != false || changed {
+		t.Errorf("Initial update yields (%v, %v), expected (Off, false)", v, changed)
+	}
+
+	// Flapping within the window does not change the stable value.
+	steps := []struct {
+		ms    int
+		value DPT_1001
+	}{
+		{100, true}, {200, false}, {300, true}, {400, false}, {600, true}, {900, false},
+	}
+	for _, step := range steps {
+		if v, changed := d.Update(step.value, at(step.ms)); v != false || changed {
+			t.Errorf("Flapping update at %d ms yields (%v, %v), expected (Off, false)", step.ms, v, changed)
+		}
+	}
+
+	// A value that stays stable beyond the window is reported exactly once.
+	if v, changed := d.Update(true, at(1000)); v != false || changed {
+		t.Errorf("Update at 1000 ms yields (%v, %v), expected (Off, false)", v, changed)
+	}
+	if v, changed := d.Update(true, at(1300)); v != false || changed {
+		t.Errorf("Update at 1300 ms yields (%v, %v), expected (Off, false)", v, changed)
+	}
+	if v, changed := d.Update(true, at(1500)); v != true || !changed {
+		t.Errorf("Update at 1500 ms yields (%v, %v), expected (On, true)", v, changed)
+	}
+	if v, changed := d.Update(true, at(1600)); v != true || changed {
+		t.Errorf("Update at 1600 ms yields (%v, %v), expected (On, false)", v, changed)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	start := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	r := RateLimiter{Interval: time.Second, Threshold: 0.5}
+
+	steps := []struct {
+		ms      int
+		value   DPT_9001
+		allowed bool
+	}{
+		{0, 21, true},       // First value is always sent.
+		{200, 21.25, false}, // Small change within the interval is suppressed.
+		{400, 20.75, false},
+		{600, 22, true},     // Significant change is forced.
+		{800, 22.25, false}, // Interval restarts with the forced value.
+		{1500, 22.25, false},
+		{1600, 22.25, true}, // Interval has passed.
+		{1700, invalidTemp, true},
+		{1800, invalidTemp, false},
+	}
+
+	for _, step := range steps {
+		if allowed := r.Allow(step.value, at(step.ms)); allowed != step.allowed {
+			t.Errorf("Update to %v at %d ms yields %v, expected %v", step.value, step.ms, allowed, step.allowed)
+		}
+	}
+}
+
+func TestSlewLimiter(t *testing.T) {
+	start := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	s := SlewLimiter{Rate: 20}
+
+	steps := []struct {
+		ms       int
+		target   DPT_5001
+		expected DPT_5001
+	}{
+		{0, 100, 0},
+		{1000, 100, 20},
+		{2500, 100, 50},
+		{5000, 100, 100},
+		{6000, 100, 100},
+		{6100, 90, 98},
+		{7000, 90, 90},
+		{7000, 0, 90},
+		{5000, 0, 90},
+		{6000, 0, 70},
+	}
+
+	for _, step := range steps {
+		if v := s.Step(step.target, at(step.ms)); abs(float32(v-step.expected)) > epsilon {
+			t.Errorf("Step towards %v at %d ms yields %v, expected %v", step.target, step.ms, v, step.expected)
+		}
+	}
+}
+
+func TestDimController(t *testing.T) {
+	var c DimController
+
+	if _, send := c.Release(); send {
+		t.Errorf("Release without press yields a telegram")
+	}
+
+	if v, send := c.Press(true); !send || v != (DPT_3007{Increase: true, Value: 1}) {
+		t.Errorf("Press yields (%v, %v), expected (Increase by 1, true)", v, send)
+	}
+	if _, send := c.Press(true); send {
+		t.Errorf("Repeated press yields a telegram")
+	}
+	if v, send := c.Release(); !send || v != (DPT_3007{Increase: true, Value: 0}) {
+		t.Errorf("Release yields (%v, %v), expected (Increase by 0, true)", v, send)
+	}
+	if _, send := c.Release(); send {
+		t.Errorf("Repeated release yields a telegram")
+	}
+
+	if v, send := c.Press(false); !send || v != (DPT_3007{Increase: false, Value: 1}) {
+		t.Errorf("Press yields (%v, %v), expected (Decrease by 1, true)", v, send)
+	}
+	if v, send := c.Press(true); !send || v != (DPT_3007{Increase: true, Value: 1}) {
+		t.Errorf("Press in opposite direction yields (%v, %v), expected (Increase by 1, true)", v, send)
+	}
+	if v, send := c.Release(); !send || v.Value != 0 || !bytes.Equal(v.Pack(), []byte{0x08}) {
+		t.Errorf("Release yields (%v, %v), expected stop telegram 0x08", v, send)
+	}
+}
+
+func TestMergeBool(t *testing.T) {
+	cases := []struct {
+		mode     MergeMode
+		values   []DPT_1001
+		expected DPT_1001
+	}{
+		{MergeOr, []DPT_1001{false, true, false}, true},
+		{MergeOr, []DPT_1001{false, false}, false},
+		{MergeAnd, []DPT_1001{true, true, true}, true},
+		{MergeAnd, []DPT_1001{true, false, true}, false},
+		{MergeLast, []DPT_1001{true, true, false}, false},
+		{MergeLast, []DPT_1001{false, true}, true},
+		{MergeOr, nil, false},
+		{MergeAnd, nil, false},
+		{MergeLast, nil, false},
+	}
+
+	for _, c := range cases {
+		if v := MergeBool(c.mode, c.values...); v != c.expected {
+			t.Errorf("MergeBool(%d, %v) = %v, expected %v", c.mode, c.values, v, c.expected)
+		}
+	}
+}
+
+func TestEdge(t *testing.T) {
+	cases := []struct {
+		prev, cur DPT_1001
+		expected  EdgeKind
+	}{
+		{DPT_1001_Off, DPT_1001_Off, EdgeNone},
+		{DPT_1001_On, DPT_1001_On, EdgeNone},
+		{DPT_1001_Off, DPT_1001_On, EdgeRising},
+		{DPT_1001_On, DPT_1001_Off, EdgeFalling},
+	}
+
+	for _, c := range cases {
+		if edge := Edge(c.prev, c.cur); edge != c.expected {
+			t.Errorf("Edge(%v, %v) = %d, expected %d", c.prev, c.cur, edge, c.expected)
+		}
+	}
+}
+
+func TestPID(t *testing.T) {
+	start := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// A room at 15 °C with 10 °C outside. Every percent of valve opening raises the equilibrium
+	// temperature by 0.2 K, so holding 21 °C requires 55%.
+	room := func(temp DPT_9001, valve DPT_5001, dt float64) DPT_9001 {
+		return temp + DPT_9001((10+0.2*float64(valve)-float64(temp))*dt/600)
+	}
+
+	p := PID{Kp: 10, Ki: 0.01}
+	temp := DPT_9001(15)
+
+	var valve DPT_5001
+	for i := 0; i < 5000; i++ {
+		valve = p.Update(21, temp, start.Add(time.Duration(i)*10*time.Second))
+		if valve < 0 || valve > 100 {
+			t.Fatalf("Control value %v is out of range", valve)
+		}
+		temp = room(temp, valve, 10)
+	}
+
+	if abs(float32(temp-21)) > 0.05 {
+		t.Errorf("Temperature settles at %v, expected 21 °C", temp)
+	}
+	if abs(float32(valve-55)) > 0.5 {
+		t.Errorf("Control value settles at %v, expected 55%%", valve)
+	}
+
+	// An invalid measurement keeps the control value.
+	if v := p.Update(21, invalidTemp, start.Add(50000*time.Second)); v != valve {
+		t.Errorf("Invalid measurement yields %v, expected %v", v, valve)
+	}
+}
+
+func TestPIDAntiWindup(t *testing.T) {
+	start := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	p := PID{Kp: 10, Ki: 0.01}
+
+	// An unreachable setpoint saturates the output for a long time.
+	for i := 0; i < 1000; i++ {
+		if v := p.Update(30, 20, start.Add(time.Duration(i)*time.Minute)); i > 0 && v != 100 {
+			t.Fatalf("Control value is %v while saturated, expected 100%%", v)
+		}
+	}
+
+	// Once the temperature overshoots, the output must drop at once instead of unwinding a huge
+	// integral first.
+	if v := p.Update(30, 31, start.Add(1000*time.Minute)); v >= 100 {
+		t.Errorf("Control value is %v after overshoot, expected less than 100%%", v)
+	}
+}
+
+func TestButtonMapping(t *testing.T) {
+	m := ButtonMapping{
+		1: {Switch: DPT_1001_On},
+		2: {Switch: DPT_1001_Off},
+		3: {Scene: true, Number: 4},
+	}
+
+	if v, ok := m.Press(1); !ok {
+		t.Errorf("Button 1 is not mapped")
+	} else if sw, ok := v.(*DPT_1001); !ok || *sw != DPT_1001_On {
+		t.Errorf("Button 1 yields %v, expected On", v)
+	}
+
+	if v, ok := m.Press(2); !ok {
+		t.Errorf("Button 2 is not mapped")
+	} else if sw, ok := v.(*DPT_1001); !ok || *sw != DPT_1001_Off {
+		t.Errorf("Button 2 yields %v, expected Off", v)
+	}
+
+	if v, ok := m.Press(3); !ok {
+		t.Errorf("Button 3 is not mapped")
+	} else if scene, ok := v.(*DPT_18001); !ok || *scene != (DPT_18001{Scene: 4}) {
+		t.Errorf("Button 3 yields %v, expected scene 5", v)
+	} else if !bytes.Equal(v.Pack(), []byte{0, 4}) {
+		t.Errorf("Button 3 packs to %v, expected [0 4]", v.Pack())
+	}
+
+	if _, ok := m.Press(4); ok {
+		t.Errorf("Unmapped button 4 yields a telegram")
+	}
+
+	// Telegrams do not share state with the mapping.
+	v, _ := m.Press(1)
+	*v.(*DPT_1001) = DPT_1001_Off
+	if v, _ := m.Press(1); *v.(*DPT_1001) != DPT_1001_On {
+		t.Errorf("Modifying a telegram changes the mapping")
+	}
+}
+
+func TestPositionEstimator(t *testing.T) {
+	start := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(s float64) time.Time { return start.Add(time.Duration(s * float64(time.Second))) }
+
+	e := PositionEstimator{TravelTime: 40 * time.Second}
+
+	// Full close.
+	e.Apply(BlindsMove(DPT_1008_Down, 1), at(0))
+	if p := e.PositionAt(at(10)); abs(float32(p)-25) > epsilon {
+		t.Errorf("Position after 10 s is %v, expected 25%%", p)
+	}
+	if p := e.PositionAt(at(60)); abs(float32(p)-100) > epsilon {
+		t.Errorf("Position after 60 s is %v, expected 100%%", p)
+	}
+	e.Apply(BlindsMove(DPT_1008_Down, 0), at(60))
+	if p := e.Position(); abs(float32(p)-100) > epsilon {
+		t.Errorf("Position after full close is %v, expected 100%%", p)
+	}
+
+	// Open and stop half way.
+	e.Apply(BlindsMove(DPT_1008_Up, 1), at(100))
+	e.Apply(BlindsMove(DPT_1008_Up, 0), at(120))
+	if p := e.Position(); abs(float32(p)-50) > epsilon {
+		t.Errorf("Position after stop is %v, expected 50%%", p)
+	}
+	if p := e.PositionAt(at(200)); abs(float32(p)-50) > epsilon {
+		t.Errorf("Position of stopped blinds changes to %v", p)
+	}
+
+	// Step code 3 moves by a quarter of the range.
+	e.Apply(DPT_3008{Down: true, Value: 3}, at(200))
+	if p := e.PositionAt(at(230)); abs(float32(p)-75) > epsilon {
+		t.Errorf("Position after step is %v, expected 75%%", p)
+	}
+
+	e.Reset(10)
+	if p := e.PositionAt(at(300)); abs(float32(p)-10) > epsilon {
+		t.Errorf("Position after reset is %v, expected 10%%", p)
+	}
+}
+
+func TestPresenceHold(t *testing.T) {
+	start := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(s int) time.Time { return start.Add(time.Duration(s) * time.Second) }
+
+	p := PresenceHold{Hold: 5 * time.Minute}
+
+	steps := []struct {
+		s        int
+		motion   DPT_1001
+		occupied DPT_1001
+	}{
+		{0, false, false},
+		{10, true, true},
+		{20, false, true},
+		{200, false, true},
+		{250, true, true}, // Motion extends the hold.
+		{500, false, true},
+		{549, false, true},
+		{550, false, false}, // Hold has elapsed.
+		{600, false, false},
+		{610, true, true},
+	}
+
+	for _, step := range steps {
+		if occupied := p.Update(step.motion, at(step.s)); occupied != step.occupied {
+			t.Errorf("Update with motion %v at %d s yields %v, expected %v", step.motion, step.s, occupied, step.occupied)
+		}
+	}
+}
+
+func TestThermostat(t *testing.T) {
+	th := Thermostat{Setpoint: 21, Deadband: 1}
+
+	steps := []struct {
+		temp    DPT_9001
+		heating DPT_1001
+	}{
+		{21, false},
+		{20.6, false},
+		{20.4, true}, // Below the deadband
+		{20.6, true},
+		{21, true},
+		{21.4, true},
+		{21.6, false}, // Above the deadband
+		{21.4, false},
+		{21, false},
+		{20.6, false},
+		{20.4, true},
+		{invalidTemp, false},
+	}
+
+	for _, step := range steps {
+		if heating := th.Update(step.temp); heating != step.heating {
+			t.Errorf("Update(%v) = %v, expected %v", step.temp, heating, step.heating)
+		}
+	}
+
+	// A signal oscillating within the deadband must not toggle the output.
+	th = Thermostat{Setpoint: 21, Deadband: 1}
+	th.Update(20)
+
+	for i := 0; i < 100; i++ {
+		temp := DPT_9001(21 + 0.4*math.Sin(float64(i)/3))
+		if heating := th.Update(temp); !heating {
+			t.Fatalf("Update(%v) toggled the output within the deadband", temp)
+		}
+	}
+}
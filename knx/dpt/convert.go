@@ -0,0 +1,376 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"errors"
+	"math"
+	"reflect"
+)
+
+// BoolValue reads a 1-bit value (DPT 1.xxx) as bool. The second result is false if the value is
+// not a 1-bit value.
+func BoolValue(value DatapointValue) (bool, bool) {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if v.Kind() != reflect.Bool {
+		return false, false
+	}
+
+	return v.Bool(), true
+}
+
+// ErrOutOfRange is returned when a value lies outside of the range of the datapoint type.
+var ErrOutOfRange = errors.New("Value is out of range for the datapoint type")
+
+// NumericValue is implemented by the numeric datapoint types (DPT 5.xxx, 7.xxx, 8.xxx, 9.xxx,
+// 12.xxx, 13.xxx and 14.xxx). It allows driving any of them from a single float64, e.g. a slider.
+type NumericValue interface {
+	DatapointValue
+
+	// AsFloat returns the value as float64.
+	AsFloat() float64
+
+	// SetFloat assigns the value. It fails with ErrOutOfRange if the value lies outside of the
+	// range of the datapoint type. Integer types round to the nearest integer. NaN is only
+	// accepted by the float types, for which it is the invalid value.
+	SetFloat(f float64) error
+}
+
+// checkRange makes sure f lies within [min, max]. NaN is rejected unless allowNaN is set.
+func checkRange(f, min, max float64, allowNaN bool) error {
+	if f != f {
+		if allowNaN {
+			return nil
+		}
+
+		return ErrOutOfRange
+	}
+
+	if f < min || f > max {
+		return ErrOutOfRange
+	}
+
+	return nil
+}
+
+// A floatRange is the range of values of a float datapoint type. Types which clamp their value
+// when packing use the same range, so SetFloat accepts exactly the values that can be sent.
+type floatRange struct {
+	min, max float64
+
+	// allowNaN accepts NaN, which is the invalid value of the type.
+	allowNaN bool
+}
+
+var (
+	scalingRange = floatRange{min: 0, max: 100}
+
+	// Angles are normalized into [0, 360) when packing, so any finite angle is accepted.
+	angleRange = floatRange{min: -math.MaxFloat32, max: math.MaxFloat32}
+
+	temperatureRange = floatRange{min: -273, max: 670760, allowNaN: true}
+	f16Range         = floatRange{min: -670760, max: 670760, allowNaN: true}
+	f16PositiveRange = floatRange{min: 0, max: 670760, allowNaN: true}
+	f32Range         = floatRange{min: -math.MaxFloat32, max: math.MaxFloat32, allowNaN: true}
+)
+
+// clamp limits f to the range. NaN is passed through.
+func (r floatRange) clamp(f float32) float32 {
+	if float64(f) < r.min {
+		return float32(r.min)
+	} else if float64(f) > r.max {
+		return float32(r.max)
+	}
+
+	return f
+}
+
+// setFloat assigns f to the float value which v points to, if it lies within the range.
+func setFloat(v interface{}, f float64, r floatRange) error {
+	if err := checkRange(f, r.min, r.max, r.allowNaN); err != nil {
+		return err
+	}
+
+	reflect.ValueOf(v).Elem().SetFloat(f)
+	return nil
+}
+
+// setInteger assigns f rounded to the nearest integer to the integer value which v points to, if
+// it lies within the range of the integer type.
+func setInteger(v interface{}, f float64) error {
+	value := reflect.ValueOf(v).Elem()
+	bits := value.Type().Bits()
+
+	switch value.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		limit := math.Ldexp(1, bits-1)
+		if err := checkRange(f, -limit, limit-1, false); err != nil {
+			return err
+		}
+
+		value.SetInt(int64(math.Floor(f + 0.5)))
+
+	default:
+		limit := math.Ldexp(1, bits)
+		if err := checkRange(f, 0, limit-1, false); err != nil {
+			return err
+		}
+
+		value.SetUint(uint64(math.Floor(f + 0.5)))
+	}
+
+	return nil
+}
+
+func (d DPT_5001) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_5001) SetFloat(f float64) error {
+	return setFloat(d, f, scalingRange)
+}
+
+func (d DPT_5003) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_5003) SetFloat(f float64) error {
+	return setFloat(d, f, angleRange)
+}
+
+func (d DPT_5004) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_5004) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_7002) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_7002) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+// AsFloat returns the time period in milliseconds, like String.
+func (d DPT_7003) AsFloat() float64 {
+	return float64(d) * 10
+}
+
+// SetFloat assigns a time period in milliseconds, rounded to the nearest 10 ms.
+func (d *DPT_7003) SetFloat(f float64) error {
+	return setInteger(d, f/10)
+}
+
+// AsFloat returns the time period in milliseconds, like String.
+func (d DPT_7004) AsFloat() float64 {
+	return float64(d) * 100
+}
+
+// SetFloat assigns a time period in milliseconds, rounded to the nearest 100 ms.
+func (d *DPT_7004) SetFloat(f float64) error {
+	return setInteger(d, f/100)
+}
+
+func (d DPT_7010) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_7010) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_7011) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_7011) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_7012) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_7012) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_7013) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_7013) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_8002) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_8002) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_9001) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_9001) SetFloat(f float64) error {
+	return setFloat(d, f, temperatureRange)
+}
+
+func (d DPT_9002) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_9002) SetFloat(f float64) error {
+	return setFloat(d, f, f16Range)
+}
+
+func (d DPT_9004) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_9004) SetFloat(f float64) error {
+	return setFloat(d, f, f16PositiveRange)
+}
+
+func (d DPT_9007) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_9007) SetFloat(f float64) error {
+	return setFloat(d, f, f16PositiveRange)
+}
+
+func (d DPT_12001) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_12001) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_13001) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_13001) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_13002) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_13002) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_13010) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_13010) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_13011) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_13011) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_13012) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_13012) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_13013) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_13013) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_13014) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_13014) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_13015) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_13015) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_13100) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_13100) SetFloat(f float64) error {
+	return setInteger(d, f)
+}
+
+func (d DPT_14002) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_14002) SetFloat(f float64) error {
+	return setFloat(d, f, f32Range)
+}
+
+func (d DPT_14003) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_14003) SetFloat(f float64) error {
+	return setFloat(d, f, f32Range)
+}
+
+func (d DPT_14006) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_14006) SetFloat(f float64) error {
+	return setFloat(d, f, f32Range)
+}
+
+func (d DPT_14007) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_14007) SetFloat(f float64) error {
+	return setFloat(d, f, f32Range)
+}
+
+func (d DPT_14056) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_14056) SetFloat(f float64) error {
+	return setFloat(d, f, f32Range)
+}
+
+func (d DPT_14065) AsFloat() float64 {
+	return float64(d)
+}
+
+func (d *DPT_14065) SetFloat(f float64) error {
+	return setFloat(d, f, f32Range)
+}
@@ -0,0 +1,117 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestBoolValue(t *testing.T) {
+	for _, id := range []string{"1.001", "1.003", "1.009", "1.017"} {
+		value, _ := Produce(id)
+
+		value.Unpack([]byte{1})
+		if b, ok := BoolValue(value); !ok || !b {
+			t.Errorf("BoolValue of true %s yields (%v, %v)", id, b, ok)
+		}
+
+		value.Unpack([]byte{0})
+		if b, ok := BoolValue(value); !ok || b {
+			t.Errorf("BoolValue of false %s yields (%v, %v)", id, b, ok)
+		}
+	}
+
+	temp := DPT_9001(1)
+	if _, ok := BoolValue(&temp); ok {
+		t.Errorf("BoolValue accepts DPT_9001")
+	}
+
+	step := DPT_3007{Increase: true, Value: 1}
+	if _, ok := BoolValue(&step); ok {
+		t.Errorf("BoolValue accepts DPT_3007")
+	}
+}
+
+func TestNumericValue(t *testing.T) {
+	// Time periods are set in milliseconds, but only have a resolution of 10 ms or 100 ms.
+	tolerances := map[string]float64{"7.003": 5, "7.004": 50}
+
+	for id, factory := range registry {
+		if !strings.HasPrefix(id, "5.") && !strings.HasPrefix(id, "7.") &&
+			!strings.HasPrefix(id, "8.") && !strings.HasPrefix(id, "9.") &&
+			!strings.HasPrefix(id, "12.") && !strings.HasPrefix(id, "13.") &&
+			!strings.HasPrefix(id, "14.") {
+			continue
+		}
+
+		value, ok := factory().(NumericValue)
+		if !ok {
+			t.Errorf("%s does not implement NumericValue", id)
+			continue
+		}
+
+		if err := value.SetFloat(42); err != nil {
+			t.Errorf("SetFloat(42) on %s fails: %v", id, err)
+		}
+
+		tolerance, ok := tolerances[id]
+		if !ok {
+			tolerance = 1.5
+		}
+
+		value.Unpack(value.Pack())
+		if f := value.AsFloat(); math.Abs(f-42) > tolerance {
+			t.Errorf("%s yields %v after SetFloat(42) and pack/unpack", id, f)
+		}
+	}
+
+	cases := []struct {
+		value NumericValue
+		f     float64
+		ok    bool
+	}{
+		{new(DPT_5001), 100, true},
+		{new(DPT_5001), 100.5, false},
+		{new(DPT_5001), -1, false},
+		{new(DPT_5003), 370, true},
+		{new(DPT_5003), -30, true},
+		{new(DPT_5003), math.NaN(), false},
+		{new(DPT_5003), math.Inf(1), false},
+		{new(DPT_5004), 255, true},
+		{new(DPT_5004), 256, false},
+		{new(DPT_7002), 65535, true},
+		{new(DPT_7003), 655350, true},
+		{new(DPT_7003), 655360, false},
+		{new(DPT_7002), -1, false},
+		{new(DPT_8002), -32768, true},
+		{new(DPT_8002), 32768, false},
+		{new(DPT_9001), -273, true},
+		{new(DPT_9001), -274, false},
+		{new(DPT_9001), math.NaN(), true},
+		{new(DPT_9007), -1, false},
+		{new(DPT_12001), math.MaxUint32, true},
+		{new(DPT_12001), -1, false},
+		{new(DPT_13001), math.NaN(), false},
+		{new(DPT_13001), math.MinInt32, true},
+		{new(DPT_13001), math.MaxInt32 + 1, false},
+		{new(DPT_14065), 1e30, true},
+	}
+
+	for _, c := range cases {
+		err := c.value.SetFloat(c.f)
+		if c.ok && err != nil {
+			t.Errorf("SetFloat(%v) on %T fails: %v", c.f, c.value, err)
+		} else if !c.ok && err != ErrOutOfRange {
+			t.Errorf("SetFloat(%v) on %T yields %v instead of ErrOutOfRange", c.f, c.value, err)
+		}
+	}
+
+	var brightness DPT_7013
+	brightness.SetFloat(12.6)
+	if brightness != 13 {
+		t.Errorf("SetFloat(12.6) on DPT_7013 yields %d instead of 13", brightness)
+	}
+}
@@ -5,17 +5,38 @@ package dpt
 
 import (
 	"errors"
+	"math"
+	"strings"
 )
 
 // ErrInvalidLength is returned when the application data has unexpected length.
 var ErrInvalidLength = errors.New("Given application data has invalid length")
 
+// ErrInvalidLeadingOctet is returned when StrictLeadingOctet is enabled and the leading octet of
+// multi-octet application data is not zero.
+var ErrInvalidLeadingOctet = errors.New("Leading octet of application data is not zero")
+
+// StrictLeadingOctet controls how the leading octet of 32-bit application data is treated. The
+// leading octet is shared with the APCI and carries no part of the value. Packing always sets it
+// to zero. By default unpacking ignores it, so raw frames whose leading octet still contains APCI
+// bits (e.g. 0x80 for a group write) decode fine. When enabled, unpacking requires it to be zero
+// as it is in application data extracted by the cemi package.
+var StrictLeadingOctet = false
+
+// InvertBool inverts the encoding of all 1-bit values (DPT 1.xxx) when enabled. This suits
+// installations which are wired with inverted logic throughout.
+var InvertBool = false
+
 func packB1(b bool) []byte {
-	if b {
-		return []byte{1}
+	return appendB1(make([]byte, 0, 1), b)
+}
+
+func appendB1(dst []byte, b bool) []byte {
+	if b != InvertBool {
+		return append(dst, 1)
 	}
 
-	return []byte{0}
+	return append(dst, 0)
 }
 
 func unpackB1(data []byte, b *bool) error {
@@ -23,7 +44,7 @@ func unpackB1(data []byte, b *bool) error {
 		return ErrInvalidLength
 	}
 
-	*b = data[0]&1 == 1
+	*b = (data[0]&1 == 1) != InvertBool
 
 	return nil
 }
@@ -48,8 +69,16 @@ func unpackB1U3(data []byte, c *bool, v *uint8) error {
 	return nil
 }
 
+// packF16 packs a 2-octet float. NaN is packed as the invalid value marker 0x7FFF.
 func packF16(f float32) []byte {
-	buffer := []byte{0, 0, 0}
+	return appendF16(make([]byte, 0, 3), f)
+}
+
+// appendF16 appends a 2-octet float packed like packF16 to dst.
+func appendF16(dst []byte, f float32) []byte {
+	if f != f {
+		return append(dst, 0, 0x7f, 0xff)
+	}
 
 	if f > 670760.96 {
 		f = 670760.96
@@ -65,26 +94,48 @@ func packF16(f float32) []byte {
 		exp++
 	}
 
-	buffer[1] |= uint8(exp&15) << 3
+	return encodeF16(dst, signedMantissa, exp)
+}
+
+// encodeF16 appends the mantissa in the range [-2048, 2047] and the exponent encoded as 2-octet
+// float to dst.
+func encodeF16(dst []byte, signedMantissa, exp int) []byte {
+	high := uint8(exp&15) << 3
 
 	if signedMantissa < 0 {
 		signedMantissa += 2048
-		buffer[1] |= 1 << 7
+		high |= 1 << 7
 	}
 
 	mantissa := uint(signedMantissa)
 
-	buffer[1] |= uint8(mantissa>>8) & 7
-	buffer[2] |= uint8(mantissa)
-
-	return buffer
+	return append(dst, 0, high|uint8(mantissa>>8)&7, uint8(mantissa))
 }
 
+// unpackF16 unpacks a 2-octet float. The invalid value marker 0x7FFF yields NaN.
 func unpackF16(data []byte, f *float32) error {
+	var value float64
+	if err := unpackF16Float64(data, &value); err != nil {
+		return err
+	}
+
+	*f = float32(value)
+
+	return nil
+}
+
+// unpackF16Float64 unpacks a 2-octet float like unpackF16, but yields the value in float64 as it
+// is computed from mantissa and exponent.
+func unpackF16Float64(data []byte, f *float64) error {
 	if len(data) != 3 {
 		return ErrInvalidLength
 	}
 
+	if data[1] == 0x7f && data[2] == 0xff {
+		*f = math.NaN()
+		return nil
+	}
+
 	m := int(data[1]&7)<<8 | int(data[2])
 	if data[1]&128 == 128 {
 		m -= 2048
@@ -92,12 +143,38 @@ func unpackF16(data []byte, f *float32) error {
 
 	e := (data[1] >> 3) & 15
 
-	*f = 0.01 * float32(m) * float32(uint(1)<<e)
+	*f = float64(m) * float64(uint(1)<<e) / 100
 	return nil
 }
 
+// unpackF16WithStatus unpacks a 2-octet float value which may be followed by a status octet as
+// sent by some sensors. The fault flag is bit 1 of the status octet, as in DPT 21.001. Without
+// a status octet, no fault is reported.
+func unpackF16WithStatus(data []byte, value DatapointValue) (bool, error) {
+	var fault bool
+
+	switch len(data) {
+	case 3:
+	case 4:
+		fault = data[3]&2 != 0
+		data = data[:3]
+	default:
+		return false, ErrInvalidLength
+	}
+
+	if err := value.Unpack(data); err != nil {
+		return false, err
+	}
+
+	return fault, nil
+}
+
 func packU8(i uint8) []byte {
-	return []byte{0, i}
+	return appendU8(make([]byte, 0, 2), i)
+}
+
+func appendU8(dst []byte, i uint8) []byte {
+	return append(dst, 0, i)
 }
 
 func unpackU8(data []byte, i *uint8) error {
@@ -110,13 +187,66 @@ func unpackU8(data []byte, i *uint8) error {
 	return nil
 }
 
+func packU16(i uint16) []byte {
+	return appendU16(make([]byte, 0, 3), i)
+}
+
+func appendU16(dst []byte, i uint16) []byte {
+	return append(dst, 0, uint8(i>>8), uint8(i&0xff))
+}
+
+func unpackU16(data []byte, i *uint16) error {
+	if len(data) != 3 {
+		return ErrInvalidLength
+	}
+
+	*i = uint16(data[1])<<8 | uint16(data[2])
+
+	return nil
+}
+
+func packV16(i int16) []byte {
+	return appendV16(make([]byte, 0, 3), i)
+}
+
+func appendV16(dst []byte, i int16) []byte {
+	return append(dst, 0, byte((i>>8)&0xff), byte(i&0xff))
+}
+
+func unpackV16(data []byte, i *int16) error {
+	if len(data) != 3 {
+		return ErrInvalidLength
+	}
+
+	*i = int16(data[1])<<8 | int16(data[2])
+
+	return nil
+}
+
+func packU24(i uint32) []byte {
+	if i > 0xffffff {
+		i = 0xffffff
+	}
+
+	return []byte{0, uint8(i >> 16), uint8(i >> 8), uint8(i & 0xff)}
+}
+
+func unpackU24(data []byte, i *uint32) error {
+	if len(data) != 4 {
+		return ErrInvalidLength
+	}
+
+	*i = uint32(data[1])<<16 | uint32(data[2])<<8 | uint32(data[3])
+
+	return nil
+}
+
 func packU32(i uint32) []byte {
-	buffer := []byte{0, 0, 0, 0, 0}
-	buffer[1] = uint8(i >> 24)
-	buffer[2] = uint8(i >> 16)
-	buffer[3] = uint8(i >> 8)
-	buffer[4] = uint8(i & 0xff)
-	return buffer
+	return appendU32(make([]byte, 0, 5), i)
+}
+
+func appendU32(dst []byte, i uint32) []byte {
+	return append(dst, 0, uint8(i>>24), uint8(i>>16), uint8(i>>8), uint8(i&0xff))
 }
 
 func unpackU32(data []byte, i *uint32) error {
@@ -124,21 +254,61 @@ func unpackU32(data []byte, i *uint32) error {
 		return ErrInvalidLength
 	}
 
+	if StrictLeadingOctet && data[0] != 0 {
+		return ErrInvalidLeadingOctet
+	}
+
 	*i = uint32(data[1])<<24 | uint32(data[2])<<16 | uint32(data[3])<<8 | uint32(data[4])
 
 	return nil
 }
 
-func packV32(i int32) []byte {
-	b := make([]byte, 5)
+func packF32(f float32) []byte {
+	return packU32(math.Float32bits(f))
+}
+
+func appendF32(dst []byte, f float32) []byte {
+	return appendU32(dst, math.Float32bits(f))
+}
+
+func unpackF32(data []byte, f *float32) error {
+	var bits uint32
+	if err := unpackU32(data, &bits); err != nil {
+		return err
+	}
+
+	*f = math.Float32frombits(bits)
+
+	return nil
+}
+
+func packA14(s string, pad byte) []byte {
+	buffer := make([]byte, 15)
+
+	n := copy(buffer[1:], s)
+	for i := 1 + n; i < len(buffer); i++ {
+		buffer[i] = pad
+	}
+
+	return buffer
+}
 
-	b[0] = 0
-	b[1] = byte((i >> 24) & 0xff)
-	b[2] = byte((i >> 16) & 0xff)
-	b[3] = byte((i >> 8) & 0xff)
-	b[4] = byte(i & 0xff)
+func unpackA14(data []byte, s *string) error {
+	if len(data) != 15 {
+		return ErrInvalidLength
+	}
+
+	*s = strings.TrimRight(string(data[1:]), "\x00 ")
+
+	return nil
+}
+
+func packV32(i int32) []byte {
+	return appendV32(make([]byte, 0, 5), i)
+}
 
-	return b
+func appendV32(dst []byte, i int32) []byte {
+	return append(dst, 0, byte((i>>24)&0xff), byte((i>>16)&0xff), byte((i>>8)&0xff), byte(i&0xff))
 }
 
 func unpackV32(data []byte, i *int32) error {
@@ -146,7 +316,106 @@ func unpackV32(data []byte, i *int32) error {
 		return ErrInvalidLength
 	}
 
+	if StrictLeadingOctet && data[0] != 0 {
+		return ErrInvalidLeadingOctet
+	}
+
 	*i = int32(data[1])<<24 | int32(data[2])<<16 | int32(data[3])<<8 | int32(data[4])
 
 	return nil
 }
+
+func packB1U3Octet(c bool, v uint8) byte {
+	if c {
+		return 1<<3 | v&7
+	}
+
+	return v & 7
+}
+
+func unpackB1U3Octet(b byte, c *bool, v *uint8) {
+	*c = (b>>3)&1 == 1
+	*v = b & 7
+}
+
+// packScaledOctet maps a value in the range [0, max] onto a single octet, rounding to the nearest
+// octet. Values outside of the range are clamped.
+func packScaledOctet(value, max float32) uint8 {
+	if value <= 0 {
+		return 0
+	} else if value >= max {
+		return 255
+	}
+
+	return uint8(value*255/max + 0.5)
+}
+
+// unpackScaledOctet maps an octet back onto the range [0, max]. It divides by the octets per unit,
+// which yields the same values DPT 5.001 has always unpacked to.
+func unpackScaledOctet(b uint8, max float32) float32 {
+	return float32(b) / (255 / max)
+}
+
+// packAngleOctet normalizes an angle into [0, 360) and maps it onto a single octet, rounding to
+// the nearest octet.
+func packAngleOctet(angle float64) uint8 {
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+
+	return uint8(angle*255/360 + 0.5)
+}
+
+func unpackScaled(data []byte, max float32, f *float32) error {
+	var value uint8
+	if err := unpackU8(data, &value); err != nil {
+		return err
+	}
+
+	*f = unpackScaledOctet(value, max)
+
+	return nil
+}
+
+// packScaling packs a percentage as in DPT 5.001. Unlike the other scaled octets, the result is
+// truncated rather than rounded, as it has always been for this type. Use unpackScaled with a
+// maximum of 100 to unpack it.
+func packScaling(f float32) []byte {
+	return appendScaling(make([]byte, 0, 2), f)
+}
+
+func appendScaling(dst []byte, f float32) []byte {
+	if f <= 0 {
+		return appendU8(dst, 0)
+	} else if f >= 100 {
+		return appendU8(dst, 255)
+	}
+
+	return appendU8(dst, uint8(f*2.55))
+}
+
+// PackBit sets or clears the bit at the given index (0 is the least significant bit) in dst. This
+// helps building status octets which combine several 1-bit values. Indices outside of [0, 7]
+// leave dst unchanged.
+func PackBit(dst byte, bitIndex int, v bool) byte {
+	if bitIndex < 0 || bitIndex > 7 {
+		return dst
+	}
+
+	if v {
+		return dst | 1<<uint(bitIndex)
+	}
+
+	return dst &^ (1 << uint(bitIndex))
+}
+
+// UnpackBit reads the bit at the given index (0 is the least significant bit) from src. Indices
+// outside of [0, 7] yield false.
+func UnpackBit(src byte, bitIndex int) bool {
+	if bitIndex < 0 || bitIndex > 7 {
+		return false
+	}
+
+	return src&(1<<uint(bitIndex)) != 0
+}
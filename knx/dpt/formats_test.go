@@ -0,0 +1,104 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestPackBit(t *testing.T) {
+	var b byte
+
+	b = PackBit(b, 0, true)
+	b = PackBit(b, 3, true)
+	b = PackBit(b, 7, true)
+	if b != 0x89 {
+		t.Errorf("Setting bits 0, 3 and 7 yields %#02x, expected 0x89", b)
+	}
+
+	b = PackBit(b, 3, false)
+	if b != 0x81 {
+		t.Errorf("Clearing bit 3 yields %#02x, expected 0x81", b)
+	}
+
+	if PackBit(b, 8, true) != b || PackBit(b, -1, true) != b {
+		t.Errorf("Setting an out-of-range bit modified the octet")
+	}
+
+	for i := 0; i < 8; i++ {
+		expected := i == 0 || i == 7
+		if UnpackBit(b, i) != expected {
+			t.Errorf("Bit %d of %#02x is %v, expected %v", i, b, UnpackBit(b, i), expected)
+		}
+	}
+
+	if UnpackBit(0xFF, 8) || UnpackBit(0xFF, -1) {
+		t.Errorf("Reading an out-of-range bit yields true")
+	}
+}
+
+func TestInvertBool(t *testing.T) {
+	defer func(invert bool) { InvertBool = invert }(InvertBool)
+
+	for _, id := range []string{"1.001", "1.002", "1.003", "1.008", "1.009", "1.010", "1.015", "1.017"} {
+		InvertBool = false
+		value, _ := Produce(id)
+		if err := value.Unpack([]byte{1}); err != nil {
+			t.Errorf("Unpacking %s failed: %v", id, err)
+		}
+		if packed := value.Pack(); packed[0] != 1 {
+			t.Errorf("%s packs to %v without inversion, expected [1]", id, packed)
+		}
+
+		InvertBool = true
+		if packed := value.Pack(); packed[0] != 0 {
+			t.Errorf("%s packs to %v with inversion, expected [0]", id, packed)
+		}
+
+		if err := value.Unpack([]byte{0}); err != nil {
+			t.Errorf("Unpacking %s failed: %v", id, err)
+		}
+		InvertBool = false
+		if packed := value.Pack(); packed[0] != 1 {
+			t.Errorf("%s unpacked from 0 with inversion should be true, packs to %v", id, packed)
+		}
+	}
+
+	InvertBool = true
+	var dst DPT_1001
+	dst.Unpack(DPT_1001(true).Pack())
+	if !dst {
+		t.Errorf("Value does not survive pack/unpack with inversion enabled")
+	}
+}
+
+func TestPackF16(t *testing.T) {
+	// The mantissa is truncated, e.g. 67.52 is sent as 67.48 rather than 67.52.
+	cases := []struct {
+		value    float32
+		expected []byte
+	}{
+		{0.01, []byte{0, 0x00, 0x01}},
+		{-0.01, []byte{0, 0x87, 0xff}},
+		{21.5, []byte{0, 0x0c, 0x33}},
+		{-30.015, []byte{0, 0x8a, 0x24}},
+		{67.52, []byte{0, 0x16, 0x97}},
+		{-67.52, []byte{0, 0x91, 0x69}},
+		{700000, []byte{0, 0x7f, 0xfe}},
+		{float32(math.NaN()), []byte{0, 0x7f, 0xff}},
+	}
+
+	for _, c := range cases {
+		if buf := packF16(c.value); !bytes.Equal(buf, c.expected) {
+			t.Errorf("%v packs to %#v, expected %#v", c.value, buf, c.expected)
+		}
+	}
+
+	// Rounding for arithmetic yields the nearest value instead.
+	if f := roundF16(67.52); !bytes.Equal(packF16(f), []byte{0, 0x16, 0x98}) {
+		t.Errorf("67.52 rounds to %v, which packs to %#v", f, packF16(f))
+	}
+}
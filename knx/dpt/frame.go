@@ -0,0 +1,73 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"fmt"
+)
+
+// Concat packs the given values and concatenates the results.
+func Concat(values ...DatapointValue) []byte {
+	var buffer []byte
+
+	for _, value := range values {
+		buffer = append(buffer, value.Pack()...)
+	}
+
+	return buffer
+}
+
+// Split decodes a sequence of values that has been assembled using Concat. The identifiers
+// determine the datapoint types of the values in the order in which they appear.
+func Split(data []byte, ids ...string) ([]DatapointValue, error) {
+	values := make([]DatapointValue, 0, len(ids))
+
+	for _, id := range ids {
+		value, ok := Produce(id)
+		if !ok {
+			return nil, fmt.Errorf("Unknown datapoint type \"%s\"", id)
+		}
+
+		length, _ := PayloadLength(id)
+		if length < 0 {
+			if length, ok = value.(variableLengthValue).packedLength(data); !ok {
+				return nil, ErrInvalidLength
+			}
+		}
+
+		if len(data) < length {
+			return nil, ErrInvalidLength
+		}
+
+		if err := value.Unpack(data[:length]); err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+		data = data[length:]
+	}
+
+	if len(data) > 0 {
+		return nil, ErrInvalidLength
+	}
+
+	return values, nil
+}
+
+// GroupPayload returns the application data that carries the given value in a group write or
+// response. Values of up to 6 bits (e.g. DPT 1.xxx or 3.xxx) are carried inline in the lower
+// bits of the first octet, which is shared with the APCI. Larger values start after the first
+// octet. The upper two bits of the first octet are always cleared so they can hold the APCI.
+func GroupPayload(value DatapointValue) []byte {
+	packed := value.Pack()
+	if len(packed) == 0 {
+		return []byte{0}
+	}
+
+	payload := make([]byte, len(packed))
+	copy(payload, packed)
+	payload[0] &= 63
+
+	return payload
+}
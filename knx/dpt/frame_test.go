@@ -0,0 +1,97 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"testing"
+)
+
+func TestConcatSplit(t *testing.T) {
+	sw := DPT_1001(true)
+	temp := DPT_9001(21.5)
+	counter := DPT_12001(4711)
+
+	data := Concat(&sw, &temp, &counter)
+	if len(data) != 1+3+5 {
+		t.Errorf("Concatenated data has length %d, expected %d", len(data), 1+3+5)
+	}
+
+	values, err := Split(data, "1.001", "9.001", "12.001")
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+
+	if len(values) != 3 {
+		t.Fatalf("Split returned %d values, expected 3", len(values))
+	}
+
+	if v, ok := values[0].(*DPT_1001); !ok || *v != sw {
+		t.Errorf("Wrong first value \"%v\", expected \"%v\"", values[0], sw)
+	}
+	if v, ok := values[1].(*DPT_9001); !ok || abs(float32(*v-temp)) > epsilon {
+		t.Errorf("Wrong second value \"%v\", expected \"%v\"", values[1], temp)
+	}
+	if v, ok := values[2].(*DPT_12001); !ok || *v != counter {
+		t.Errorf("Wrong third value \"%v\", expected \"%v\"", values[2], counter)
+	}
+
+	if _, err := Split(data, "1.001", "9.001"); err != ErrInvalidLength {
+		t.Errorf("Split with trailing data should fail with ErrInvalidLength, got %v", err)
+	}
+	if _, err := Split(data[:5], "1.001", "9.001", "12.001"); err != ErrInvalidLength {
+		t.Errorf("Split with missing data should fail with ErrInvalidLength, got %v", err)
+	}
+	if _, err := Split(data, "0.000"); err == nil {
+		t.Errorf("Split with unknown datapoint type should fail")
+	}
+}
+
+func TestSplitVariableLength(t *testing.T) {
+	text := DPT_28001("Grüße")
+	temp := DPT_9001(21.5)
+
+	values, err := Split(Concat(&text, &temp), "28.001", "9.001")
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+
+	if v, ok := values[0].(*DPT_28001); !ok || *v != text {
+		t.Errorf("Wrong first value \"%v\", expected \"%v\"", values[0], text)
+	}
+	if v, ok := values[1].(*DPT_9001); !ok || abs(float32(*v-temp)) > epsilon {
+		t.Errorf("Wrong second value \"%v\", expected \"%v\"", values[1], temp)
+	}
+
+	if _, err := Split([]byte{0, 'a', 'b'}, "28.001"); err != ErrInvalidLength {
+		t.Errorf("Split of unterminated string should fail with ErrInvalidLength, got %v", err)
+	}
+}
+
+func TestGroupPayload(t *testing.T) {
+	sw := DPT_1001(true)
+	if payload := GroupPayload(&sw); len(payload) != 1 || payload[0] != 1 {
+		t.Errorf("Payload for 1-bit value is %v, expected [1]", payload)
+	}
+
+	step := DPT_3007{Increase: true, Value: 5}
+	if payload := GroupPayload(&step); len(payload) != 1 || payload[0] != 0x0D {
+		t.Errorf("Payload for 4-bit value is %v, expected [13]", payload)
+	}
+
+	temp := DPT_9001(21.5)
+	payload := GroupPayload(&temp)
+	if len(payload) != 3 || payload[0] != 0 {
+		t.Errorf("Payload for 2-octet value is %v, expected leading zero octet and length 3", payload)
+	}
+
+	var dst DPT_9001
+	if err := dst.Unpack(payload); err != nil || abs(float32(dst-temp)) > epsilon {
+		t.Errorf("Payload for 2-octet value decodes to \"%s\" (%v), expected \"%s\"", dst, err, temp)
+	}
+
+	counter := DPT_12001(0xDEADBEEF)
+	if payload := GroupPayload(&counter); len(payload) != 5 || payload[0] != 0 {
+		t.Errorf("Payload for 4-octet value is %v, expected leading zero octet and length 5", payload)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// marshalF16JSON encodes a 2-octet float value as JSON number. The invalid value becomes null.
+func marshalF16JSON(f float32) ([]byte, error) {
+	if f != f {
+		return []byte("null"), nil
+	}
+
+	return []byte(strconv.FormatFloat(float64(f), 'f', -1, 32)), nil
+}
+
+// unmarshalF16JSON decodes a JSON number into a 2-octet float value. null yields the invalid value.
+func unmarshalF16JSON(data []byte, f *float32) error {
+	if string(data) == "null" {
+		*f = float32(math.NaN())
+		return nil
+	}
+
+	return json.Unmarshal(data, f)
+}
+
+// MarshalJSON encodes the temperature as JSON number, or null if it is invalid.
+func (d DPT_9001) MarshalJSON() ([]byte, error) {
+	return marshalF16JSON(float32(d))
+}
+
+// UnmarshalJSON decodes the temperature from a JSON number. null yields the invalid value.
+func (d *DPT_9001) UnmarshalJSON(data []byte) error {
+	return unmarshalF16JSON(data, (*float32)(d))
+}
+
+// MarshalJSON encodes the temperature difference as JSON number, or null if it is invalid.
+func (d DPT_9002) MarshalJSON() ([]byte, error) {
+	return marshalF16JSON(float32(d))
+}
+
+// UnmarshalJSON decodes the temperature difference from a JSON number. null yields the invalid
+// value.
+func (d *DPT_9002) UnmarshalJSON(data []byte) error {
+	return unmarshalF16JSON(data, (*float32)(d))
+}
+
+// MarshalJSON encodes the illuminance as JSON number, or null if it is invalid.
+func (d DPT_9004) MarshalJSON() ([]byte, error) {
+	return marshalF16JSON(float32(d))
+}
+
+// UnmarshalJSON decodes the illuminance from a JSON number. null yields the invalid value.
+func (d *DPT_9004) UnmarshalJSON(data []byte) error {
+	return unmarshalF16JSON(data, (*float32)(d))
+}
+
+// MarshalJSON encodes the humidity as JSON number, or null if it is invalid.
+func (d DPT_9007) MarshalJSON() ([]byte, error) {
+	return marshalF16JSON(float32(d))
+}
+
+// UnmarshalJSON decodes the humidity from a JSON number. null yields the invalid value.
+func (d *DPT_9007) UnmarshalJSON(data []byte) error {
+	return unmarshalF16JSON(data, (*float32)(d))
+}
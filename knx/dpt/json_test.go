@@ -0,0 +1,66 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestF16JSON(t *testing.T) {
+	type reading struct {
+		Temp  DPT_9001
+		Shift DPT_9002
+		Lux   DPT_9004
+	}
+
+	src := reading{Temp: 21.5, Shift: -1.25, Lux: 400}
+
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("Marshalling %+v failed: %v", src, err)
+	}
+	if string(data) != `{"Temp":21.5,"Shift":-1.25,"Lux":400}` {
+		t.Errorf("Marshalling %+v yields %s", src, data)
+	}
+
+	var dst reading
+	if err := json.Unmarshal(data, &dst); err != nil || dst != src {
+		t.Errorf("Unmarshalling %s yields (%+v, %v)", data, dst, err)
+	}
+
+	// Invalid values are encoded as null and back.
+	invalid := reading{
+		Temp:  DPT_9001(math.NaN()),
+		Shift: DPT_9002(math.NaN()),
+		Lux:   DPT_9004(math.NaN()),
+	}
+
+	data, err = json.Marshal(invalid)
+	if err != nil {
+		t.Fatalf("Marshalling invalid values failed: %v", err)
+	}
+	if string(data) != `{"Temp":null,"Shift":null,"Lux":null}` {
+		t.Errorf("Marshalling invalid values yields %s", data)
+	}
+
+	dst = src
+	if err := json.Unmarshal(data, &dst); err != nil {
+		t.Errorf("Unmarshalling %s failed: %v", data, err)
+	}
+	if dst.Temp.Valid() || dst.Shift.Valid() || dst.Lux.Valid() {
+		t.Errorf("Unmarshalling %s yields valid values %+v", data, dst)
+	}
+
+	var temp DPT_9001
+	temp.Unpack([]byte{0, 0x7f, 0xff})
+	if data, _ := json.Marshal(temp); string(data) != "null" {
+		t.Errorf("Invalid value marker is marshalled as %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`"warm"`), &temp); err == nil {
+		t.Errorf("Unmarshalling a string succeeds")
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownType is returned when parsing a value of a datapoint type which is not registered.
+var ErrUnknownType = errors.New("Unknown datapoint type")
+
+// ErrNotParsable is returned when parsing a value of a datapoint type which has no textual form.
+var ErrNotParsable = errors.New("Datapoint type cannot be parsed from text")
+
+// ErrInvalidSyntax is returned when the text does not represent a value of the datapoint type.
+var ErrInvalidSyntax = errors.New("Invalid syntax for datapoint value")
+
+// ParseValue parses the textual form of a value of the datapoint type with the given identifier.
+// Numeric types accept decimal numbers, subject to the range rules of SetFloat. The number may be
+// followed by the unit of the type, e.g. "21.5 °C", "21.5°C" or "21.5 C". 1-bit types accept
+// the forms understood by strconv.ParseBool as well as their labels (e.g. "On" or "Close"), HVAC
+// modes accept their names and string types take the text as it is. Letter case is ignored.
+func ParseValue(id string, s string) (DatapointValue, error) {
+	value, ok := Produce(id)
+	if !ok {
+		return nil, ErrUnknownType
+	}
+
+	s = strings.TrimSpace(s)
+
+	if mode, ok := value.(*DPT_20102); ok {
+		if parsed, err := ParseHVACMode(s); err == nil {
+			*mode = parsed
+			return value, nil
+		}
+	}
+
+	if num, ok := value.(NumericValue); ok {
+		f, err := strconv.ParseFloat(stripUnit(s, num), 64)
+		if err != nil {
+			return nil, ErrInvalidSyntax
+		}
+
+		if err := num.SetFloat(f); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(value))
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if err := parseBool(value, v, s); err != nil {
+			return nil, err
+		}
+
+	case reflect.Uint8:
+		i, err := strconv.ParseUint(s, 10, 8)
+		if err != nil {
+			return nil, ErrInvalidSyntax
+		}
+
+		v.SetUint(i)
+
+	case reflect.String:
+		v.SetString(s)
+
+	default:
+		return nil, ErrNotParsable
+	}
+
+	return value, nil
+}
+
+// stripUnit removes the unit of the given value from the end of s, if present. A leading degree sign
+// of the unit may be omitted, e.g. "C" matches "°C".
+func stripUnit(s string, value DatapointValue) string {
+	meta, ok := value.(DatapointMeta)
+	if !ok || meta.Unit() == "" {
+		return s
+	}
+
+	units := []string{meta.Unit()}
+	if short := strings.TrimPrefix(meta.Unit(), "°"); short != meta.Unit() && short != "" {
+		units = append(units, short)
+	}
+
+	lower := strings.ToLower(s)
+	for _, unit := range units {
+		if strings.HasSuffix(lower, strings.ToLower(unit)) {
+			return strings.TrimSpace(s[:len(s)-len(unit)])
+		}
+	}
+
+	return s
+}
+
+// parseBool assigns the boolean represented by s to v, which is the underlying value of value.
+func parseBool(value DatapointValue, v reflect.Value, s string) error {
+	if b, err := strconv.ParseBool(s); err == nil {
+		v.SetBool(b)
+		return nil
+	}
+
+	if str, ok := value.(fmt.Stringer); ok {
+		for _, b := range []bool{true, false} {
+			v.SetBool(b)
+			if strings.EqualFold(str.String(), s) {
+				return nil
+			}
+		}
+	}
+
+	return ErrInvalidSyntax
+}
+
+// A LogScanner reads a textual log of datapoint values. Each line consists of a group address and
+// a value separated by whitespace, e.g. "1/2/3 21.5". The address is looked up in a map of
+// datapoint type identifiers and the value is parsed using ParseValue. Empty lines, lines starting
+// with '#' and lines with addresses that are not in the map are skipped.
+type LogScanner struct {
+	scanner *bufio.Scanner
+	types   map[string]string
+	line    int
+	address string
+	value   DatapointValue
+	err     error
+}
+
+// NewLogScanner creates a LogScanner reading from r. The map types assigns datapoint type
+// identifiers (e.g. "9.001") to group addresses.
+func NewLogScanner(r io.Reader, types map[string]string) *LogScanner {
+	return &LogScanner{
+		scanner: bufio.NewScanner(r),
+		types:   types,
+	}
+}
+
+// Scan advances to the next value, which is then available through Address and Value. It returns
+// false when the input is exhausted or an error occurred; Err tells them apart.
+func (s *LogScanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	for s.scanner.Scan() {
+		s.line++
+
+		line := strings.TrimSpace(s.scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		sep := strings.IndexAny(line, " \t")
+		if sep < 0 {
+			s.err = fmt.Errorf("Line %d: missing value", s.line)
+			return false
+		}
+
+		address := line[:sep]
+
+		id, ok := s.types[address]
+		if !ok {
+			continue
+		}
+
+		value, err := ParseValue(id, line[sep+1:])
+		if err != nil {
+			s.err = fmt.Errorf("Line %d: %v", s.line, err)
+			return false
+		}
+
+		s.address = address
+		s.value = value
+
+		return true
+	}
+
+	s.err = s.scanner.Err()
+
+	return false
+}
+
+// Address returns the group address of the current value.
+func (s *LogScanner) Address() string {
+	return s.address
+}
+
+// Value returns the current value.
+func (s *LogScanner) Value() DatapointValue {
+	return s.value
+}
+
+// Err returns the first error that occurred while scanning, if any.
+func (s *LogScanner) Err() error {
+	return s.err
+}
@@ -0,0 +1,157 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseValue(t *testing.T) {
+	cases := []struct {
+		id       string
+		text     string
+		expected string
+	}{
+		{"1.001", "On", "On"},
+		{"1.001", "off", "Off"},
+		{"1.001", "1", "On"},
+		{"1.009", "close", "Close"},
+		{"5.001", "42", "41.96%"},
+		{"5.003", "370", "9.88°"},
+		{"7.013", " 1000 ", "1000 lx"},
+		{"9.001", "21.5", "21.50 °C"},
+		{"9.001", "-3", "-3.00 °C"},
+		{"13.010", "-1500", "-1500 Wh"},
+		{"16.000", "Hello", "Hello"},
+		{"20.102", "comfort", "Comfort"},
+		{"20.102", "42", "unknown(42)"},
+	}
+
+	for _, c := range cases {
+		value, err := ParseValue(c.id, c.text)
+		if err != nil {
+			t.Errorf("Parsing \"%s\" as %s failed: %v", c.text, c.id, err)
+			continue
+		}
+
+		// Round trip through the wire format, like a value read from the bus.
+		value.Unpack(value.Pack())
+		if s := value.(interface{ String() string }).String(); s != c.expected {
+			t.Errorf("Parsing \"%s\" as %s yields \"%s\", expected \"%s\"", c.text, c.id, s, c.expected)
+		}
+	}
+
+	errorCases := []struct {
+		id   string
+		text string
+		err  error
+	}{
+		{"999.999", "1", ErrUnknownType},
+		{"1.001", "maybe", ErrInvalidSyntax},
+		{"9.001", "warm", ErrInvalidSyntax},
+		{"9.001", "-300", ErrOutOfRange},
+		{"5.001", "101", ErrOutOfRange},
+		{"20.102", "Comfy", ErrInvalidSyntax},
+		{"232.600", "#FF0000", ErrNotParsable},
+	}
+
+	for _, c := range errorCases {
+		if _, err := ParseValue(c.id, c.text); err != c.err {
+			t.Errorf("Parsing \"%s\" as %s yields %v, expected %v", c.text, c.id, err, c.err)
+		}
+	}
+}
+
+func TestLogScanner(t *testing.T) {
+	input := `# Values of the living room
+1/1/1 On
+1/2/3 21.5
+
+9/9/9 ignored
+1/2/4	45
+1/1/1 off
+`
+
+	types := map[string]string{
+		"1/1/1": "1.001",
+		"1/2/3": "9.001",
+		"1/2/4": "5.001",
+	}
+
+	expected := []struct {
+		address string
+		value   string
+	}{
+		{"1/1/1", "On"},
+		{"1/2/3", "21.50 °C"},
+		{"1/2/4", "45.00%"},
+		{"1/1/1", "Off"},
+	}
+
+	s := NewLogScanner(strings.NewReader(input), types)
+	for i, e := range expected {
+		if !s.Scan() {
+			t.Fatalf("Scan %d stops early: %v", i, s.Err())
+		}
+
+		value := s.Value().(interface{ String() string }).String()
+		if s.Address() != e.address || value != e.value {
+			t.Errorf("Scan %d yields (%s, %s), expected (%s, %s)", i, s.Address(), value, e.address, e.value)
+		}
+	}
+
+	if s.Scan() {
+		t.Errorf("Scan yields extra value (%s, %v)", s.Address(), s.Value())
+	}
+	if s.Err() != nil {
+		t.Errorf("Scan fails: %v", s.Err())
+	}
+
+	s = NewLogScanner(strings.NewReader("1/1/1 On\n1/2/3 hot\n"), types)
+	if !s.Scan() {
+		t.Fatalf("First scan fails: %v", s.Err())
+	}
+	if s.Scan() || s.Err() == nil || !strings.Contains(s.Err().Error(), "Line 2") {
+		t.Errorf("Invalid value yields error %v", s.Err())
+	}
+}
+
+func TestParseValueUnit(t *testing.T) {
+	cases := []struct {
+		id       string
+		text     string
+		expected string
+	}{
+		{"9.001", "21.5", "21.50 °C"},
+		{"9.001", "21.5°C", "21.50 °C"},
+		{"9.001", "21.5 °C", "21.50 °C"},
+		{"9.001", "21.5 C", "21.50 °C"},
+		{"9.001", "21.5c", "21.50 °C"},
+		{"9.002", "-1.5 K", "-1.50 K"},
+		{"9.004", "400 lx", "400.00 lx"},
+		{"5.001", "100 %", "100.00%"},
+		{"13.010", "1500Wh", "1500 Wh"},
+		{"7.003", "500 ms", "500 ms"},
+		{"7.004", "1500ms", "1500 ms"},
+	}
+
+	for _, c := range cases {
+		value, err := ParseValue(c.id, c.text)
+		if err != nil {
+			t.Errorf("Parsing \"%s\" as %s failed: %v", c.text, c.id, err)
+			continue
+		}
+
+		if s := value.(interface{ String() string }).String(); s != c.expected {
+			t.Errorf("Parsing \"%s\" as %s yields \"%s\", expected \"%s\"", c.text, c.id, s, c.expected)
+		}
+	}
+
+	for _, text := range []string{"21.5 K", "21.5 °F", "°C", "21.5 °C °C"} {
+		if _, err := ParseValue("9.001", text); err != ErrInvalidSyntax {
+			t.Errorf("Parsing \"%s\" as 9.001 yields %v instead of ErrInvalidSyntax", text, err)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"sync"
+)
+
+// bufferSize is the capacity of pooled buffers. It fits the largest application data that a
+// transport unit may carry.
+const bufferSize = 255
+
+// bufferPool holds pointers to buffers, so that returning a buffer does not allocate.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		buffer := make([]byte, 0, bufferSize)
+		return &buffer
+	},
+}
+
+// GetBuffer retrieves an empty buffer from the pool. Hot senders can use pooled buffers to
+// assemble outgoing application data without allocating a new buffer for every telegram:
+//
+//	buffer := dpt.GetBuffer()
+//	*buffer = dpt.AppendPack(*buffer, value)
+//	// ... hand the buffer to the sender ...
+//	dpt.PutBuffer(buffer)
+//
+// The buffer must not be used after it has been returned using PutBuffer.
+func GetBuffer() *[]byte {
+	buffer := bufferPool.Get().(*[]byte)
+	*buffer = (*buffer)[:0]
+	return buffer
+}
+
+// PutBuffer returns a buffer obtained by GetBuffer to the pool.
+func PutBuffer(buffer *[]byte) {
+	if buffer == nil || cap(*buffer) < bufferSize {
+		return
+	}
+
+	bufferPool.Put(buffer)
+}
+
+// AppendPack appends the packed value to dst. Values which implement Appender are packed without
+// allocating, others are packed using Pack.
+func AppendPack(dst []byte, value DatapointValue) []byte {
+	if appender, ok := value.(Appender); ok {
+		return appender.AppendPack(dst)
+	}
+
+	return append(dst, value.Pack()...)
+}
@@ -0,0 +1,100 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBufferPool(t *testing.T) {
+	buffer := GetBuffer()
+	if len(*buffer) != 0 {
+		t.Errorf("Buffer from pool has length %d, expected 0", len(*buffer))
+	}
+	if cap(*buffer) < bufferSize {
+		t.Errorf("Buffer from pool has capacity %d, expected at least %d", cap(*buffer), bufferSize)
+	}
+
+	*buffer = append(*buffer, DPT_9001(21.5).Pack()...)
+	PutBuffer(buffer)
+
+	buffer = GetBuffer()
+	if len(*buffer) != 0 {
+		t.Errorf("Reused buffer has length %d, expected 0", len(*buffer))
+	}
+	PutBuffer(buffer)
+
+	// Buffers that are too small must not end up in the pool.
+	small := make([]byte, 0, 1)
+	PutBuffer(&small)
+	PutBuffer(nil)
+	if buffer := GetBuffer(); cap(*buffer) < bufferSize {
+		t.Errorf("Pool returned a buffer with capacity %d", cap(*buffer))
+	}
+}
+
+func TestAppendPack(t *testing.T) {
+	prefix := []byte{0xAA}
+
+	humidity := DPT_9007(-5)
+	for _, value := range append(benchmarkValues(), &DPT_10001{Hour: 12}, &humidity) {
+		expected := append([]byte{0xAA}, value.Pack()...)
+		if buf := AppendPack(prefix[:1:1], value); !bytes.Equal(buf, expected) {
+			t.Errorf("Appending %v yields %v, expected %v", value, buf, expected)
+		}
+	}
+
+	// All types packed by the generic formats append what they pack.
+	for id := range registry {
+		value, _ := Produce(id)
+		if _, ok := value.(Appender); !ok {
+			continue
+		}
+
+		if buf := AppendPack(nil, value); !bytes.Equal(buf, value.Pack()) {
+			t.Errorf("%s appends %v, but packs to %v", id, buf, value.Pack())
+		}
+	}
+}
+
+func benchmarkValues() []DatapointValue {
+	var (
+		sw     = DPT_1001(true)
+		scale  = DPT_5001(42)
+		temp   = DPT_9001(21.5)
+		energy = DPT_13010(123456)
+	)
+
+	return []DatapointValue{&sw, &scale, &temp, &energy}
+}
+
+func BenchmarkPackFresh(b *testing.B) {
+	values := benchmarkValues()
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			var buffer []byte
+			for _, value := range values {
+				buffer = append(buffer, value.Pack()...)
+			}
+		}
+	})
+}
+
+func BenchmarkPackPooled(b *testing.B) {
+	values := benchmarkValues()
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			buffer := GetBuffer()
+			for _, value := range values {
+				*buffer = AppendPack(*buffer, value)
+			}
+			PutBuffer(buffer)
+		}
+	})
+}
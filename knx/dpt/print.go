@@ -0,0 +1,116 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// formatValue implements fmt.Formatter for datapoint values. The verb %v prints the plain value,
+// %+v adds the unit and the datapoint type identifier and %s prints the result of String. Other
+// verbs format the underlying value as usual.
+func formatValue(f fmt.State, verb rune, value interface{}, plain, str, id string) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			fmt.Fprintf(f, "%s (DPT %s)", str, id)
+		} else {
+			io.WriteString(f, plain)
+		}
+
+	case 's':
+		io.WriteString(f, str)
+
+	default:
+		fmt.Fprintf(f, formatDirective(f, verb), value)
+	}
+}
+
+// formatDirective reconstructs the format directive that has been used to format a value.
+func formatDirective(f fmt.State, verb rune) string {
+	directive := "%"
+
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			directive += string(flag)
+		}
+	}
+
+	if width, ok := f.Width(); ok {
+		directive += strconv.Itoa(width)
+	}
+
+	if precision, ok := f.Precision(); ok {
+		directive += "." + strconv.Itoa(precision)
+	}
+
+	return directive + string(verb)
+}
+
+// NameOf returns the name of the type of the given value, e.g. "DPT_9001". For nil, NameOf returns
+// an empty string.
+func NameOf(value DatapointValue) string {
+	if value == nil {
+		return ""
+	}
+
+	typ := reflect.TypeOf(value)
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ.Name()
+}
+
+// Debug describes the given value and its packed form, e.g. "DPT_9001(21.50 °C) = 0x00 0x0C 0x33".
+// Nil values are described as "<nil>".
+func Debug(value DatapointValue) string {
+	if value == nil {
+		return "<nil>"
+	} else if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr && v.IsNil() {
+		return NameOf(value) + "(<nil>)"
+	}
+
+	var str string
+	if stringer, ok := value.(fmt.Stringer); ok {
+		str = stringer.String()
+	} else {
+		str = fmt.Sprint(reflect.Indirect(reflect.ValueOf(value)).Interface())
+	}
+
+	return fmt.Sprintf("%s(%s) = %s", NameOf(value), str, hexOctets(value.Pack()))
+}
+
+// hexOctets formats the given data as space-separated hexadecimal octets, e.g. "0x0C 0x33".
+func hexOctets(data []byte) string {
+	octets := make([]string, len(data))
+	for i, b := range data {
+		octets[i] = fmt.Sprintf("0x%02X", b)
+	}
+
+	return strings.Join(octets, " ")
+}
+
+// siPrefixes are the prefixes used by humanCount, in steps of 1000.
+var siPrefixes = []string{"", "k", "M", "G"}
+
+// humanCount formats a count with an SI prefix and one decimal, e.g. "1.5M" for 1500000. Counts
+// below 1000 are formatted as they are.
+func humanCount(v float64) string {
+	prefix := 0
+	scaled := v
+
+	// Compare the rounded value, so that e.g. 999999 becomes "1M" rather than "1000k".
+	for math.Abs(RoundNearest.round(scaled*10)/10) >= 1000 && prefix < len(siPrefixes)-1 {
+		scaled /= 1000
+		prefix++
+	}
+
+	return strconv.FormatFloat(RoundNearest.round(scaled*10)/10, 'f', -1, 64) + siPrefixes[prefix]
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	temp := DPT_9001(21.5)
+
+	cases := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", "21.50"},
+		{"%+v", "21.50 °C (DPT 9.001)"},
+		{"%s", "21.50 °C"},
+		{"%.1f", "21.5"},
+		{"%6.2f", " 21.50"},
+	}
+
+	for _, c := range cases {
+		if s := fmt.Sprintf(c.format, temp); s != c.expected {
+			t.Errorf("Formatting with \"%s\" yields \"%s\", expected \"%s\"", c.format, s, c.expected)
+		}
+	}
+
+	if s := fmt.Sprintf("%+v", DPT_9004(1000)); s != "1000.00 lx (DPT 9.004)" {
+		t.Errorf("Formatting DPT_9004 with \"%%+v\" yields \"%s\"", s)
+	}
+}
+
+func TestNameOf(t *testing.T) {
+	temp := DPT_9001(21.5)
+	if name := NameOf(&temp); name != "DPT_9001" {
+		t.Errorf("Name of *DPT_9001 is \"%s\"", name)
+	}
+
+	counter := Counter24(1)
+	if name := NameOf(&counter); name != "Counter24" {
+		t.Errorf("Name of *Counter24 is \"%s\"", name)
+	}
+
+	if name := NameOf(nil); name != "" {
+		t.Errorf("Name of nil is \"%s\"", name)
+	}
+	if name := NameOf((*DPT_9001)(nil)); name != "DPT_9001" {
+		t.Errorf("Name of nil *DPT_9001 is \"%s\"", name)
+	}
+}
+
+func TestDebug(t *testing.T) {
+	temp := DPT_9001(21.5)
+	if s := Debug(&temp); s != "DPT_9001(21.50 °C) = 0x00 0x0C 0x33" {
+		t.Errorf("Debug output for DPT_9001 is \"%s\"", s)
+	}
+
+	sw := DPT_1001(true)
+	if s := Debug(&sw); s != "DPT_1001(On) = 0x01" {
+		t.Errorf("Debug output for DPT_1001 is \"%s\"", s)
+	}
+
+	custom := customType(258)
+	if s := Debug(&custom); s != "customType(258) = 0x00 0x01 0x02" {
+		t.Errorf("Debug output for value without String is \"%s\"", s)
+	}
+
+	if s := Debug(nil); s != "<nil>" {
+		t.Errorf("Debug output for nil is \"%s\"", s)
+	}
+	if s := Debug((*DPT_9001)(nil)); s != "DPT_9001(<nil>)" {
+		t.Errorf("Debug output for nil *DPT_9001 is \"%s\"", s)
+	}
+}
+
+func TestHumanString(t *testing.T) {
+	cases := []struct {
+		value    float64
+		expected string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{-999, "-999"},
+		{1000, "1k"},
+		{1500, "1.5k"},
+		{1500000, "1.5M"},
+		{1234567, "1.2M"},
+		{999999, "1M"},
+		{-2500000, "-2.5M"},
+		{math.MaxUint32, "4.3G"},
+	}
+
+	for _, c := range cases {
+		if s := humanCount(c.value); s != c.expected {
+			t.Errorf("humanCount(%v) = %q, expected %q", c.value, s, c.expected)
+		}
+	}
+
+	if s := DPT_12001(1500000).HumanString(); s != "1.5M pulses" {
+		t.Errorf("HumanString of 1500000 pulses yields %q", s)
+	}
+	if s := DPT_12001(1500000).String(); s != "1500000 pulses" {
+		t.Errorf("String of 1500000 pulses yields %q", s)
+	}
+	if s := DPT_13010(-42).HumanString(); s != "-42 Wh" {
+		t.Errorf("HumanString of -42 Wh yields %q", s)
+	}
+	if s := DPT_13013(2500).HumanString(); s != "2.5k kWh" {
+		t.Errorf("HumanString of 2500 kWh yields %q", s)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"errors"
+	"regexp"
+)
+
+// registry maps datapoint type identifiers to functions which produce a new value of that type.
+var registry = map[string]func() DatapointValue{
+	"1.001":   func() DatapointValue { return new(DPT_1001) },
+	"1.002":   func() DatapointValue { return new(DPT_1002) },
+	"1.003":   func() DatapointValue { return new(DPT_1003) },
+	"1.008":   func() DatapointValue { return new(DPT_1008) },
+	"1.009":   func() DatapointValue { return new(DPT_1009) },
+	"1.010":   func() DatapointValue { return new(DPT_1010) },
+	"1.015":   func() DatapointValue { return new(DPT_1015) },
+	"1.017":   func() DatapointValue { return new(DPT_1017) },
+	"3.007":   func() DatapointValue { return new(DPT_3007) },
+	"3.008":   func() DatapointValue { return new(DPT_3008) },
+	"5.001":   func() DatapointValue { return new(DPT_5001) },
+	"5.003":   func() DatapointValue { return new(DPT_5003) },
+	"5.004":   func() DatapointValue { return new(DPT_5004) },
+	"7.002":   func() DatapointValue { return new(DPT_7002) },
+	"7.003":   func() DatapointValue { return new(DPT_7003) },
+	"7.004":   func() DatapointValue { return new(DPT_7004) },
+	"7.010":   func() DatapointValue { return new(DPT_7010) },
+	"7.011":   func() DatapointValue { return new(DPT_7011) },
+	"7.012":   func() DatapointValue { return new(DPT_7012) },
+	"7.013":   func() DatapointValue { return new(DPT_7013) },
+	"8.002":   func() DatapointValue { return new(DPT_8002) },
+	"9.001":   func() DatapointValue { return new(DPT_9001) },
+	"9.002":   func() DatapointValue { return new(DPT_9002) },
+	"9.004":   func() DatapointValue { return new(DPT_9004) },
+	"9.007":   func() DatapointValue { return new(DPT_9007) },
+	"10.001":  func() DatapointValue { return new(DPT_10001) },
+	"12.001":  func() DatapointValue { return new(DPT_12001) },
+	"13.001":  func() DatapointValue { return new(DPT_13001) },
+	"13.002":  func() DatapointValue { return new(DPT_13002) },
+	"13.010":  func() DatapointValue { return new(DPT_13010) },
+	"13.011":  func() DatapointValue { return new(DPT_13011) },
+	"13.012":  func() DatapointValue { return new(DPT_13012) },
+	"13.013":  func() DatapointValue { return new(DPT_13013) },
+	"13.014":  func() DatapointValue { return new(DPT_13014) },
+	"13.015":  func() DatapointValue { return new(DPT_13015) },
+	"13.100":  func() DatapointValue { return new(DPT_13100) },
+	"14.002":  func() DatapointValue { return new(DPT_14002) },
+	"14.003":  func() DatapointValue { return new(DPT_14003) },
+	"14.006":  func() DatapointValue { return new(DPT_14006) },
+	"14.007":  func() DatapointValue { return new(DPT_14007) },
+	"14.056":  func() DatapointValue { return new(DPT_14056) },
+	"14.065":  func() DatapointValue { return new(DPT_14065) },
+	"15.000":  func() DatapointValue { return new(DPT_15000) },
+	"16.000":  func() DatapointValue { return new(DPT_16000) },
+	"18.001":  func() DatapointValue { return new(DPT_18001) },
+	"20.102":  func() DatapointValue { return new(DPT_20102) },
+	"20.105":  func() DatapointValue { return new(DPT_20105) },
+	"20.107":  func() DatapointValue { return new(DPT_20107) },
+	"28.001":  func() DatapointValue { return new(DPT_28001) },
+	"206.100": func() DatapointValue { return new(DPT_206100) },
+	"232.600": func() DatapointValue { return new(DPT_232600) },
+	"232.601": func() DatapointValue { return new(DPT_232601) },
+	"242.600": func() DatapointValue { return new(DPT_242600) },
+	"250.600": func() DatapointValue { return new(DPT_250600) },
+}
+
+// Produce creates a new value of the datapoint type with the given identifier (e.g. "9.001").
+func Produce(id string) (DatapointValue, bool) {
+	factory, ok := registry[id]
+	if !ok {
+		return nil, false
+	}
+
+	return factory(), true
+}
+
+// A variableLengthValue is a datapoint value whose packed length depends on the value.
+type variableLengthValue interface {
+	// packedLength determines the length of the packed value at the beginning of data.
+	packedLength(data []byte) (int, bool)
+}
+
+// PayloadLength returns the number of bytes that a packed value of the datapoint type with the
+// given identifier occupies. Types whose packed length varies yield -1.
+func PayloadLength(id string) (int, bool) {
+	value, ok := Produce(id)
+	if !ok {
+		return 0, false
+	}
+
+	if _, ok := value.(variableLengthValue); ok {
+		return -1, true
+	}
+
+	return len(value.Pack()), true
+}
+
+// UnitOf returns the unit of the datapoint type with the given identifier. Types without a unit
+// yield an empty string.
+func UnitOf(id string) (string, bool) {
+	value, ok := Produce(id)
+	if !ok {
+		return "", false
+	}
+
+	if meta, ok := value.(DatapointMeta); ok {
+		return meta.Unit(), true
+	}
+
+	return "", true
+}
+
+// ErrInvalidTypeID is returned when registering a datapoint type with a malformed identifier.
+var ErrInvalidTypeID = errors.New("Invalid datapoint type identifier")
+
+// ErrTypeRegistered is returned when registering a datapoint type whose identifier is taken.
+var ErrTypeRegistered = errors.New("Datapoint type is already registered")
+
+// typeIDPattern matches datapoint type identifiers such as "9.001".
+var typeIDPattern = regexp.MustCompile(`^[0-9]+\.[0-9]{3}$`)
+
+// Register adds a user-defined datapoint type to the registry, after which Produce and the other
+// lookup functions recognize it. The identifier must have the form "main.sub" (e.g. "999.001")
+// and must not be in use already. Register is meant to be called during initialization; it must
+// not be called concurrently with other functions that use the registry.
+func Register(id string, factory func() DatapointValue) error {
+	if !typeIDPattern.MatchString(id) || factory == nil {
+		return ErrInvalidTypeID
+	}
+
+	if _, ok := registry[id]; ok {
+		return ErrTypeRegistered
+	}
+
+	registry[id] = factory
+
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestProduce(t *testing.T) {
+	for id := range registry {
+		value, ok := Produce(id)
+		if !ok || value == nil {
+			t.Errorf("Failed to produce a value for \"%s\"", id)
+			continue
+		}
+
+		if err := value.Unpack(value.Pack()); err != nil {
+			t.Errorf("Produced value for \"%s\" does not unpack its own packed form: %v", id, err)
+		}
+	}
+
+	if _, ok := Produce("0.000"); ok {
+		t.Errorf("Produced a value for an unknown datapoint type")
+	}
+}
+
+func TestPackedComparison(t *testing.T) {
+	for id := range registry {
+		a, _ := Produce(id)
+		b, _ := Produce(id)
+
+		if !bytes.Equal(a.Pack(), b.Pack()) {
+			t.Errorf("Zero values of \"%s\" pack differently", id)
+		}
+
+		// Packing is stable across a round trip.
+		packed := a.Pack()
+		if err := b.Unpack(packed); err != nil {
+			t.Errorf("Unpacking \"%s\" failed: %v", id, err)
+		}
+		if !bytes.Equal(b.Pack(), packed) {
+			t.Errorf("Packed form of \"%s\" changes across a round trip", id)
+		}
+	}
+
+	x, y, z := DPT_9001(21.5), DPT_9001(21.5), DPT_9001(22)
+	if !bytes.Equal(x.Pack(), y.Pack()) {
+		t.Errorf("Equal temperatures pack differently")
+	}
+	if bytes.Equal(x.Pack(), z.Pack()) {
+		t.Errorf("Different temperatures pack equally")
+	}
+}
+
+func TestPayloadLength(t *testing.T) {
+	expected := map[string]int{
+		"1.001":   1,
+		"3.007":   1,
+		"5.001":   2,
+		"9.001":   3,
+		"12.001":  5,
+		"13.010":  5,
+		"250.600": 4,
+		"28.001":  -1,
+	}
+
+	for id, length := range expected {
+		if l, ok := PayloadLength(id); !ok || l != length {
+			t.Errorf("Payload length of \"%s\" is %d, expected %d", id, l, length)
+		}
+	}
+
+	if _, ok := PayloadLength("0.000"); ok {
+		t.Errorf("Payload length reported for an unknown datapoint type")
+	}
+}
+
+func TestUnitOf(t *testing.T) {
+	expected := map[string]string{
+		"1.001":  "",
+		"5.001":  "%",
+		"9.001":  "°C",
+		"9.004":  "lx",
+		"13.010": "Wh",
+	}
+
+	for id, unit := range expected {
+		if u, ok := UnitOf(id); !ok || u != unit {
+			t.Errorf("Unit of \"%s\" is \"%s\", expected \"%s\"", id, u, unit)
+		}
+	}
+
+	if _, ok := UnitOf("0.000"); ok {
+		t.Errorf("Unit reported for an unknown datapoint type")
+	}
+}
+
+type customType uint16
+
+func (d customType) Pack() []byte {
+	return packU16(uint16(d))
+}
+
+func (d *customType) Unpack(data []byte) error {
+	return unpackU16(data, (*uint16)(d))
+}
+
+func (d customType) Unit() string {
+	return "widgets"
+}
+
+func TestRegister(t *testing.T) {
+	defer delete(registry, "999.001")
+
+	factory := func() DatapointValue { return new(customType) }
+
+	if err := Register("999.001", factory); err != nil {
+		t.Fatalf("Registering custom type failed: %v", err)
+	}
+
+	value, ok := Produce("999.001")
+	if !ok {
+		t.Fatalf("Failed to produce registered custom type")
+	}
+	if _, ok := value.(*customType); !ok {
+		t.Errorf("Produced value has type %T, expected *customType", value)
+	}
+
+	if unit, ok := UnitOf("999.001"); !ok || unit != "widgets" {
+		t.Errorf("Unit of custom type is \"%s\", expected \"widgets\"", unit)
+	}
+
+	if err := Register("999.001", factory); err != ErrTypeRegistered {
+		t.Errorf("Registering a duplicate should fail with ErrTypeRegistered, got %v", err)
+	}
+	if err := Register("9.001", factory); err != ErrTypeRegistered {
+		t.Errorf("Registering a built-in identifier should fail with ErrTypeRegistered, got %v", err)
+	}
+
+	for _, id := range []string{"", "999", "999.1", "999.0001", "a.001", "999.001 "} {
+		if err := Register(id, factory); err != ErrInvalidTypeID {
+			t.Errorf("Registering \"%s\" should fail with ErrInvalidTypeID, got %v", id, err)
+		}
+	}
+
+	if err := Register("999.002", nil); err != ErrInvalidTypeID {
+		t.Errorf("Registering a nil factory should fail with ErrInvalidTypeID, got %v", err)
+	}
+}
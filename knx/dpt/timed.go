@@ -0,0 +1,129 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"time"
+)
+
+// Timestamped is a datapoint value together with the time at which it has been received.
+type Timestamped struct {
+	Value    DatapointValue
+	Received time.Time
+}
+
+// Stale determines whether the value has not been updated within the given time to live.
+func (t Timestamped) Stale(ttl time.Duration, now time.Time) bool {
+	return now.Sub(t.Received) > ttl
+}
+
+// TimedValue is a datapoint value to be sent with a delay relative to the start of a sequence.
+type TimedValue struct {
+	Value DatapointValue
+	At    time.Duration
+}
+
+// Pulse generates the sequence for a momentary relay: on immediately, then off after the given
+// duration.
+func Pulse(duration time.Duration) []TimedValue {
+	on, off := DPT_1001_On, DPT_1001_Off
+
+	return []TimedValue{
+		{Value: &on, At: 0},
+		{Value: &off, At: duration},
+	}
+}
+
+// Fade generates the sequence for a linear fade from one brightness to another over the given
+// duration, with one value per update interval. The sequence starts with from immediately and ends
+// with to after the duration. If the duration or the update interval is not positive, the sequence
+// only consists of to.
+func Fade(from, to DPT_5001, dur time.Duration, rate time.Duration) []TimedValue {
+	if dur <= 0 || rate <= 0 {
+		return []TimedValue{{Value: &to, At: 0}}
+	}
+
+	steps := int((dur + rate - 1) / rate)
+	seq := make([]TimedValue, 0, steps+1)
+
+	for i := 0; i < steps; i++ {
+		at := time.Duration(i) * rate
+		value := from + (to-from)*DPT_5001(float64(at)/float64(dur))
+		seq = append(seq, TimedValue{Value: &value, At: at})
+	}
+
+	return append(seq, TimedValue{Value: &to, At: dur})
+}
+
+// A History keeps the most recent timestamped values of a datapoint, e.g. for trend charts. Once
+// it is full, adding a value discards the oldest one.
+type History struct {
+	entries []Timestamped
+	next    int
+	full    bool
+}
+
+// NewHistory creates a History which holds up to size values.
+func NewHistory(size int) *History {
+	if size < 1 {
+		size = 1
+	}
+
+	return &History{entries: make([]Timestamped, size)}
+}
+
+// Add records a value received at the given time.
+func (h *History) Add(value DatapointValue, received time.Time) {
+	h.entries[h.next] = Timestamped{Value: value, Received: received}
+
+	h.next++
+	if h.next == len(h.entries) {
+		h.next = 0
+		h.full = true
+	}
+}
+
+// Len returns the number of values in the history.
+func (h *History) Len() int {
+	if h.full {
+		return len(h.entries)
+	}
+
+	return h.next
+}
+
+// Slice returns a copy of the values in the history, oldest first.
+func (h *History) Slice() []Timestamped {
+	if !h.full {
+		return append([]Timestamped(nil), h.entries[:h.next]...)
+	}
+
+	result := make([]Timestamped, 0, len(h.entries))
+	result = append(result, h.entries[h.next:]...)
+	result = append(result, h.entries[:h.next]...)
+
+	return result
+}
+
+// Power computes the average power in watts between two timestamped DPT_13010 energy readings.
+// The second result is false if either value is not a DPT_13010 or cur has not been received
+// after prev.
+func Power(prev, cur Timestamped) (float64, bool) {
+	prevEnergy, ok := prev.Value.(*DPT_13010)
+	if !ok {
+		return 0, false
+	}
+
+	curEnergy, ok := cur.Value.(*DPT_13010)
+	if !ok {
+		return 0, false
+	}
+
+	elapsed := cur.Received.Sub(prev.Received)
+	if elapsed <= 0 {
+		return 0, false
+	}
+
+	return (float64(*curEnergy) - float64(*prevEnergy)) / elapsed.Hours(), true
+}
@@ -0,0 +1,173 @@
+// Copyright 2017 Ole Krüger.
+// Licensed under the MIT license which can be found in the LICENSE file.
+
+package dpt
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTimestampedStale(t *testing.T) {
+	received := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	temp := DPT_9001(21.5)
+	value := Timestamped{Value: &temp, Received: received}
+
+	if value.Stale(time.Minute, received.Add(30*time.Second)) {
+		t.Errorf("Value received 30s ago is stale with a TTL of 1m")
+	}
+	if value.Stale(time.Minute, received.Add(time.Minute)) {
+		t.Errorf("Value received exactly 1m ago is stale with a TTL of 1m")
+	}
+	if !value.Stale(time.Minute, received.Add(61*time.Second)) {
+		t.Errorf("Value received 61s ago is not stale with a TTL of 1m")
+	}
+
+	if v, ok := value.Value.(*DPT_9001); !ok || *v != temp {
+		t.Errorf("Timestamped value does not hold the original value")
+	}
+}
+
+func TestPulse(t *testing.T) {
+	seq := Pulse(500 * time.Millisecond)
+	if len(seq) != 2 {
+		t.Fatalf("Pulse yields %d values, expected 2", len(seq))
+	}
+
+	expected := []struct {
+		value DPT_1001
+		at    time.Duration
+	}{
+		{true, 0},
+		{false, 500 * time.Millisecond},
+	}
+
+	for i, e := range expected {
+		v, ok := seq[i].Value.(*DPT_1001)
+		if !ok || *v != e.value || seq[i].At != e.at {
+			t.Errorf("Pulse value %d is %v at %v, expected %v at %v", i, seq[i].Value, seq[i].At, e.value, e.at)
+		}
+	}
+}
+
+func TestFade(t *testing.T) {
+	cases := []struct {
+		from, to  DPT_5001
+		dur, rate time.Duration
+		steps     int
+	}{
+		{0, 100, time.Second, 100 * time.Millisecond, 11},
+		{80, 20, 2 * time.Second, 500 * time.Millisecond, 5},
+		{0, 100, time.Second, 300 * time.Millisecond, 5}, // Last interval is shorter.
+		{0, 100, time.Second, 2 * time.Second, 2},
+		{40, 60, 0, 100 * time.Millisecond, 1},
+		{40, 60, time.Second, 0, 1},
+	}
+
+	for _, c := range cases {
+		seq := Fade(c.from, c.to, c.dur, c.rate)
+		if len(seq) != c.steps {
+			t.Errorf("Fade(%v, %v, %v, %v) yields %d values, expected %d", c.from, c.to, c.dur, c.rate, len(seq), c.steps)
+			continue
+		}
+
+		first, last := seq[0], seq[len(seq)-1]
+		if c.steps > 1 {
+			if v, ok := first.Value.(*DPT_5001); !ok || *v != c.from || first.At != 0 {
+				t.Errorf("Fade starts with %v at %v, expected %v at 0", first.Value, first.At, c.from)
+			}
+		}
+
+		if v, ok := last.Value.(*DPT_5001); !ok || *v != c.to || (c.steps > 1 && last.At != c.dur) {
+			t.Errorf("Fade ends with %v at %v, expected %v at %v", last.Value, last.At, c.to, c.dur)
+		}
+	}
+
+	// Intermediate values progress linearly.
+	seq := Fade(0, 100, time.Second, 250*time.Millisecond)
+	for i, expected := range []DPT_5001{0, 25, 50, 75, 100} {
+		v, ok := seq[i].Value.(*DPT_5001)
+		if !ok || abs(float32(*v-expected)) > epsilon || seq[i].At != time.Duration(i)*250*time.Millisecond {
+			t.Errorf("Fade value %d is %v at %v, expected %v", i, seq[i].Value, seq[i].At, expected)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	start := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	h := NewHistory(3)
+
+	if h.Len() != 0 || len(h.Slice()) != 0 {
+		t.Errorf("New history is not empty")
+	}
+
+	for i := 0; i < 5; i++ {
+		temp := DPT_9001(20 + i)
+		h.Add(&temp, start.Add(time.Duration(i)*time.Minute))
+
+		expectedLen := i + 1
+		if expectedLen > 3 {
+			expectedLen = 3
+		}
+		if h.Len() != expectedLen {
+			t.Errorf("History holds %d values after %d additions, expected %d", h.Len(), i+1, expectedLen)
+		}
+	}
+
+	entries := h.Slice()
+	if len(entries) != 3 {
+		t.Fatalf("History yields %d values, expected 3", len(entries))
+	}
+
+	for i, entry := range entries {
+		expected := DPT_9001(22 + i)
+		if v, ok := entry.Value.(*DPT_9001); !ok || *v != expected {
+			t.Errorf("Entry %d holds %v, expected %v", i, entry.Value, expected)
+		}
+		if at := start.Add(time.Duration(2+i) * time.Minute); !entry.Received.Equal(at) {
+			t.Errorf("Entry %d has been received at %v, expected %v", i, entry.Received, at)
+		}
+	}
+
+	// The slice is a copy.
+	entries[0].Value = nil
+	if h.Slice()[0].Value == nil {
+		t.Errorf("Modifying the slice changes the history")
+	}
+}
+
+func TestPower(t *testing.T) {
+	start := time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC)
+	prevEnergy, curEnergy := DPT_13010(3600000), DPT_13010(3601000)
+
+	prev := Timestamped{Value: &prevEnergy, Received: start}
+	cur := Timestamped{Value: &curEnergy, Received: start.Add(time.Hour)}
+
+	if p, ok := Power(prev, cur); !ok || math.Abs(p-1000) > 1e-9 {
+		t.Errorf("1 kWh over 1 hour yields (%v, %v), expected 1000 W", p, ok)
+	}
+
+	cur.Received = start.Add(15 * time.Minute)
+	if p, ok := Power(prev, cur); !ok || math.Abs(p-4000) > 1e-9 {
+		t.Errorf("1 kWh over 15 minutes yields (%v, %v), expected 4000 W", p, ok)
+	}
+
+	prevEnergy, curEnergy = -2e9, 2e9
+	cur.Received = start.Add(time.Hour)
+	if p, ok := Power(prev, cur); !ok || math.Abs(p-4e9) > 1e-3 {
+		t.Errorf("4e9 Wh over 1 hour yields (%v, %v), expected 4e9 W", p, ok)
+	}
+
+	if _, ok := Power(cur, prev); ok {
+		t.Errorf("Power accepts readings in reverse order")
+	}
+	if _, ok := Power(prev, prev); ok {
+		t.Errorf("Power accepts readings without elapsed time")
+	}
+
+	temp := DPT_9001(21.5)
+	if _, ok := Power(prev, Timestamped{Value: &temp, Received: start.Add(time.Hour)}); ok {
+		t.Errorf("Power accepts DPT_9001")
+	}
+}
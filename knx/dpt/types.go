@@ -4,12 +4,21 @@
 package dpt
 
 import (
+	"bytes"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // A DatapointValue is a value of a datapoint.
 type DatapointValue interface {
-	// Pack the datapoint to a byte array.
+	// Pack the datapoint to a byte array. The result is the current encoding of the value, so
+	// two values of the same type are equal on the wire if their packed forms are equal, e.g.
+	// according to bytes.Equal.
 	Pack() []byte
 
 	// Unpack a the datapoint value from a byte array.
@@ -22,13 +31,30 @@ type DatapointMeta interface {
 	Unit() string
 }
 
+// An Appender packs a datapoint by appending the result to an existing buffer. Together with
+// pooled buffers, this avoids allocating a new buffer for every value.
+type Appender interface {
+	// AppendPack appends the packed datapoint to dst and returns the extended buffer.
+	AppendPack(dst []byte) []byte
+}
+
 // DPT_1001 represents DPT 1.001 / Switch.
 type DPT_1001 bool
 
+// These are the named values of DPT_1001.
+const (
+	DPT_1001_Off DPT_1001 = false
+	DPT_1001_On  DPT_1001 = true
+)
+
 func (d DPT_1001) Pack() []byte {
 	return packB1(bool(d))
 }
 
+func (d DPT_1001) AppendPack(dst []byte) []byte {
+	return appendB1(dst, bool(d))
+}
+
 func (d *DPT_1001) Unpack(data []byte) error {
 	return unpackB1(data, (*bool)(d))
 }
@@ -45,13 +71,42 @@ func (d DPT_1001) String() string {
 	}
 }
 
+// Not returns the inverted value.
+func (d DPT_1001) Not() DPT_1001 {
+	return !d
+}
+
+// NewDPT_1001FromInt creates a DPT_1001 from an integer. 0 yields false, any other value true.
+func NewDPT_1001FromInt(i int) DPT_1001 {
+	return i != 0
+}
+
+// Int returns 1 for true and 0 for false.
+func (d DPT_1001) Int() int {
+	if d {
+		return 1
+	}
+
+	return 0
+}
+
 // DPT_1002 represents DPT 1.002 / Bool.
 type DPT_1002 bool
 
+// These are the named values of DPT_1002.
+const (
+	DPT_1002_False DPT_1002 = false
+	DPT_1002_True  DPT_1002 = true
+)
+
 func (d DPT_1002) Pack() []byte {
 	return packB1(bool(d))
 }
 
+func (d DPT_1002) AppendPack(dst []byte) []byte {
+	return appendB1(dst, bool(d))
+}
+
 func (d *DPT_1002) Unpack(data []byte) error {
 	return unpackB1(data, (*bool)(d))
 }
@@ -68,13 +123,42 @@ func (d DPT_1002) String() string {
 	}
 }
 
+// Not returns the inverted value.
+func (d DPT_1002) Not() DPT_1002 {
+	return !d
+}
+
+// NewDPT_1002FromInt creates a DPT_1002 from an integer. 0 yields false, any other value true.
+func NewDPT_1002FromInt(i int) DPT_1002 {
+	return i != 0
+}
+
+// Int returns 1 for true and 0 for false.
+func (d DPT_1002) Int() int {
+	if d {
+		return 1
+	}
+
+	return 0
+}
+
 // DPT_1003 represents DPT 1.003 / Enable.
 type DPT_1003 bool
 
+// These are the named values of DPT_1003.
+const (
+	DPT_1003_Disable DPT_1003 = false
+	DPT_1003_Enable  DPT_1003 = true
+)
+
 func (d DPT_1003) Pack() []byte {
 	return packB1(bool(d))
 }
 
+func (d DPT_1003) AppendPack(dst []byte) []byte {
+	return appendB1(dst, bool(d))
+}
+
 func (d *DPT_1003) Unpack(data []byte) error {
 	return unpackB1(data, (*bool)(d))
 }
@@ -91,13 +175,42 @@ func (d DPT_1003) String() string {
 	}
 }
 
+// Not returns the inverted value.
+func (d DPT_1003) Not() DPT_1003 {
+	return !d
+}
+
+// NewDPT_1003FromInt creates a DPT_1003 from an integer. 0 yields false, any other value true.
+func NewDPT_1003FromInt(i int) DPT_1003 {
+	return i != 0
+}
+
+// Int returns 1 for true and 0 for false.
+func (d DPT_1003) Int() int {
+	if d {
+		return 1
+	}
+
+	return 0
+}
+
 // DPT_1008 represents DPT 1.008 / UpDown.
 type DPT_1008 bool
 
+// These are the named values of DPT_1008.
+const (
+	DPT_1008_Down DPT_1008 = false
+	DPT_1008_Up   DPT_1008 = true
+)
+
 func (d DPT_1008) Pack() []byte {
 	return packB1(bool(d))
 }
 
+func (d DPT_1008) AppendPack(dst []byte) []byte {
+	return appendB1(dst, bool(d))
+}
+
 func (d *DPT_1008) Unpack(data []byte) error {
 	return unpackB1(data, (*bool)(d))
 }
@@ -114,13 +227,42 @@ func (d DPT_1008) String() string {
 	}
 }
 
+// Not returns the inverted value.
+func (d DPT_1008) Not() DPT_1008 {
+	return !d
+}
+
+// NewDPT_1008FromInt creates a DPT_1008 from an integer. 0 yields false, any other value true.
+func NewDPT_1008FromInt(i int) DPT_1008 {
+	return i != 0
+}
+
+// Int returns 1 for true and 0 for false.
+func (d DPT_1008) Int() int {
+	if d {
+		return 1
+	}
+
+	return 0
+}
+
 // DPT_1009 represents DPT 1.009 / OpenClose.
 type DPT_1009 bool
 
+// These are the named values of DPT_1009.
+const (
+	DPT_1009_Open  DPT_1009 = false
+	DPT_1009_Close DPT_1009 = true
+)
+
 func (d DPT_1009) Pack() []byte {
 	return packB1(bool(d))
 }
 
+func (d DPT_1009) AppendPack(dst []byte) []byte {
+	return appendB1(dst, bool(d))
+}
+
 func (d *DPT_1009) Unpack(data []byte) error {
 	return unpackB1(data, (*bool)(d))
 }
@@ -137,13 +279,42 @@ func (d DPT_1009) String() string {
 	}
 }
 
+// Not returns the inverted value.
+func (d DPT_1009) Not() DPT_1009 {
+	return !d
+}
+
+// NewDPT_1009FromInt creates a DPT_1009 from an integer. 0 yields false, any other value true.
+func NewDPT_1009FromInt(i int) DPT_1009 {
+	return i != 0
+}
+
+// Int returns 1 for true and 0 for false.
+func (d DPT_1009) Int() int {
+	if d {
+		return 1
+	}
+
+	return 0
+}
+
 // DPT_1010 represents DPT 1.010 / Start.
 type DPT_1010 bool
 
+// These are the named values of DPT_1010.
+const (
+	DPT_1010_Stop  DPT_1010 = false
+	DPT_1010_Start DPT_1010 = true
+)
+
 func (d DPT_1010) Pack() []byte {
 	return packB1(bool(d))
 }
 
+func (d DPT_1010) AppendPack(dst []byte) []byte {
+	return appendB1(dst, bool(d))
+}
+
 func (d *DPT_1010) Unpack(data []byte) error {
 	return unpackB1(data, (*bool)(d))
 }
@@ -160,6 +331,132 @@ func (d DPT_1010) String() string {
 	}
 }
 
+// Not returns the inverted value.
+func (d DPT_1010) Not() DPT_1010 {
+	return !d
+}
+
+// NewDPT_1010FromInt creates a DPT_1010 from an integer. 0 yields false, any other value true.
+func NewDPT_1010FromInt(i int) DPT_1010 {
+	return i != 0
+}
+
+// Int returns 1 for true and 0 for false.
+func (d DPT_1010) Int() int {
+	if d {
+		return 1
+	}
+
+	return 0
+}
+
+// DPT_1015 represents DPT 1.015 / Reset.
+//
+// This type is edge-triggered: only true causes the receiver to reset, false is a no-op.
+type DPT_1015 bool
+
+// These are the named values of DPT_1015.
+const (
+	DPT_1015_NoAction DPT_1015 = false
+	DPT_1015_Reset    DPT_1015 = true
+)
+
+func (d DPT_1015) Pack() []byte {
+	return packB1(bool(d))
+}
+
+func (d DPT_1015) AppendPack(dst []byte) []byte {
+	return appendB1(dst, bool(d))
+}
+
+func (d *DPT_1015) Unpack(data []byte) error {
+	return unpackB1(data, (*bool)(d))
+}
+
+func (d DPT_1015) Unit() string {
+	return ""
+}
+
+func (d DPT_1015) String() string {
+	if d {
+		return "Reset"
+	} else {
+		return "No action"
+	}
+}
+
+// Not returns the inverted value.
+func (d DPT_1015) Not() DPT_1015 {
+	return !d
+}
+
+// NewDPT_1015FromInt creates a DPT_1015 from an integer. 0 yields false, any other value true.
+func NewDPT_1015FromInt(i int) DPT_1015 {
+	return i != 0
+}
+
+// Int returns 1 for true and 0 for false.
+func (d DPT_1015) Int() int {
+	if d {
+		return 1
+	}
+
+	return 0
+}
+
+// DPT_1017 represents DPT 1.017 / Trigger.
+//
+// This type is edge-triggered: only true triggers the receiver, false is a no-op.
+type DPT_1017 bool
+
+// These are the named values of DPT_1017.
+const (
+	DPT_1017_Trigger DPT_1017 = true
+)
+
+func (d DPT_1017) Pack() []byte {
+	return packB1(bool(d))
+}
+
+func (d DPT_1017) AppendPack(dst []byte) []byte {
+	return appendB1(dst, bool(d))
+}
+
+func (d *DPT_1017) Unpack(data []byte) error {
+	return unpackB1(data, (*bool)(d))
+}
+
+func (d DPT_1017) Unit() string {
+	return ""
+}
+
+func (d DPT_1017) String() string {
+	if d {
+		return "Trigger"
+	} else {
+		return "—"
+	}
+}
+
+// Not returns the inverted value.
+func (d DPT_1017) Not() DPT_1017 {
+	return !d
+}
+
+// NewDPT_1017FromInt creates a DPT_1017 from an integer. 0 yields false, any other value true.
+func NewDPT_1017FromInt(i int) DPT_1017 {
+	return i != 0
+}
+
+// Int returns 1 for true and 0 for false.
+func (d DPT_1017) Int() int {
+	if d {
+		return 1
+	}
+
+	return 0
+}
+
 // DPT_3007 represents DPT 3.007 / Direction(Increase/Decrease) Value
 type DPT_3007 struct {
 	Increase bool
@@ -195,334 +492,2450 @@ func (d DPT_3007) String() string {
 	}
 }
 
-// DPT_5001 represents DPT 5.001 / Scaling.
-type DPT_5001 float32
+// NewDPT_3007FromNibble creates a DPT_3007 from the raw 4-bit nibble it is packed into. Bit 3 is
+// the control bit, bits 2 to 0 are the step code. The upper 4 bits are ignored.
+func NewDPT_3007FromNibble(n uint8) DPT_3007 {
+	var d DPT_3007
+	unpackB1U3Octet(n, &d.Increase, &d.Value)
+	return d
+}
 
-func (d DPT_5001) Pack() []byte {
-	if d <= 0 {
-		return packU8(0)
-	} else if d >= 100 {
-		return packU8(255)
-	} else {
-		return packU8(uint8(d * 2.55))
-	}
+// Nibble returns the raw 4-bit nibble (control bit and step code) the value is packed into.
+func (d DPT_3007) Nibble() uint8 {
+	return packB1U3Octet(d.Increase, d.Value)
 }
 
-func (d *DPT_5001) Unpack(data []byte) error {
-	var value uint8
-	if err := unpackU8(data, &value); err != nil {
-		return err
-	}
+// DPT_3008 represents DPT 3.008 / Direction(Up/Down) Blinds
+//
+// A step code (Value) of 0 stops the movement; otherwise it divides the full range into
+// 2^(Value-1) intervals.
+type DPT_3008 struct {
+	Down  bool
+	Value uint8
+}
 
-	*d = DPT_5001(value) / 2.55
+func (d DPT_3008) Pack() []byte {
+	return packB1U3(d.Down, d.Value)
+}
 
+func (d *DPT_3008) Unpack(data []byte) error {
+	var down bool
+	var val uint8
+	if err := unpackB1U3(data, &down, &val); err != nil {
+		return err
+	}
+	*d = DPT_3008{
+		Down:  down,
+		Value: val,
+	}
 	return nil
 }
 
-func (d DPT_5001) Unit() string {
-	return "%"
+func (d DPT_3008) Unit() string {
+	return ""
 }
 
-func (d DPT_5001) String() string {
-	return fmt.Sprintf("%.2f%%", float32(d))
+func (d DPT_3008) String() string {
+	if d.Value == 0 {
+		return "Stop"
+	} else if d.Down {
+		return fmt.Sprintf("Down by %d", d.Value)
+	} else {
+		return fmt.Sprintf("Up by %d", d.Value)
+	}
 }
 
-// DPT_5003 represents DPT 5.003 / Angle.
-type DPT_5003 float32
+// BlindsMove creates the DPT_3008 step telegram which moves blinds in the given direction. The
+// step code 0 stops the blinds.
+func BlindsMove(dir DPT_1008, step uint8) DPT_3008 {
+	return DPT_3008{Down: !bool(dir), Value: step & 7}
+}
 
-func (d DPT_5003) Pack() []byte {
-	if d <= 0 {
-		return packU8(0)
-	} else if d >= 360 {
-		return packU8(255)
-	} else {
-		return packU8(uint8(d * (255 / 360)))
-	}
+// DPT_5001 represents DPT 5.001 / Scaling.
+type DPT_5001 float32
+
+func (d DPT_5001) Pack() []byte {
+	return packScaling(float32(d))
 }
 
-func (d *DPT_5003) Unpack(data []byte) error {
-	var value uint8
-	if err := unpackU8(data, &value); err != nil {
-		return err
-	}
+func (d DPT_5001) AppendPack(dst []byte) []byte {
+	return appendScaling(dst, float32(d))
+}
 
-	*d = DPT_5003(value) / (255 / 360)
+func (d *DPT_5001) Unpack(data []byte) error {
+	return unpackScaled(data, 100, (*float32)(d))
+}
 
-	return nil
+func (d DPT_5001) Unit() string {
+	return "%"
 }
 
-func (d DPT_5003) Unit() string {
-	return "°"
+func (d DPT_5001) String() string {
+	return fmt.Sprintf("%.2f%%", float32(d))
 }
 
-func (d DPT_5003) String() string {
-	return fmt.Sprintf("%.2f°", float32(d))
+// Quantize returns the value as it is after a pack/unpack round trip, i.e. as it is transmitted.
+func (d DPT_5001) Quantize() DPT_5001 {
+	unpack := func(data []byte, f *float32) error { return unpackScaled(data, 100, f) }
+
+	return DPT_5001(quantize(float32(d), packScaling, unpack))
 }
 
-// DPT_5004 represents DPT 5.004 / Percent_U8.
-type DPT_5004 uint8
+// NewDPT_5001FromFraction creates a DPT_5001 from a fraction in the range [0, 1].
+func NewDPT_5001FromFraction(f float32) DPT_5001 {
+	return DPT_5001(f * 100)
+}
 
-func (d DPT_5004) Pack() []byte {
-	return packU8(uint8(d))
+// Fraction returns the value as a fraction in the range [0, 1].
+func (d DPT_5001) Fraction() float32 {
+	return float32(d) / 100
 }
 
-func (d *DPT_5004) Unpack(data []byte) error {
-	return unpackU8(data, (*uint8)(d))
+// Gamma applies a power curve with the given exponent to the value, e.g. to map the linear
+// brightness of a user interface onto a perceptually smooth LED dimming value. Values outside of
+// [0%, 100%] are clamped first.
+func (d DPT_5001) Gamma(g float32) DPT_5001 {
+	if d <= 0 {
+		return 0
+	} else if d >= 100 {
+		return 100
+	}
+
+	return DPT_5001(100 * math.Pow(float64(d)/100, float64(g)))
 }
 
-func (d DPT_5004) Unit() string {
-	return "%"
+// Decibels maps the value logarithmically onto the level range [minDb, maxDb], e.g. for audio
+// volume. 0% yields minDb, 100% yields maxDb and the curve is log10(1 + 9p), where p is the value
+// as a fraction, so that lower settings change the level more. Values outside of [0%, 100%] are
+// clamped.
+func (d DPT_5001) Decibels(minDb, maxDb float32) float32 {
+	p := math.Max(0, math.Min(1, float64(d)/100))
+	return minDb + (maxDb-minDb)*float32(math.Log10(1+9*p))
 }
 
-func (d DPT_5004) String() string {
-	return fmt.Sprintf("%.2f%%", float32(d))
+// DPT_5001Auto is a DPT_5001 for actuators which use the raw octet 255 as a sentinel for automatic
+// operation instead of 100%. The remaining octets are scaled like DPT_5001, so the greatest value
+// that can be transmitted is 254 (99.6%). Plain DPT_5001 always treats 255 as 100%.
+type DPT_5001Auto struct {
+	Value DPT_5001
+	Auto  bool
 }
 
-// DPT_9001 represents DPT 9.001 / Temperature.
-type DPT_9001 float32
+func (d DPT_5001Auto) Pack() []byte {
+	if d.Auto {
+		return packU8(255)
+	}
 
-func (d DPT_9001) Pack() []byte {
-	if d <= -273 {
-		return packF16(-273)
-	} else if d >= 670760 {
-		return packF16(670760)
-	} else {
-		return packF16(float32(d))
+	buffer := d.Value.Pack()
+	if buffer[1] == 255 {
+		buffer[1] = 254
 	}
+
+	return buffer
 }
 
-func (d *DPT_9001) Unpack(data []byte) error {
-	var value float32
-	if err := unpackF16(data, &value); err != nil {
-		return err
+func (d *DPT_5001Auto) Unpack(data []byte) error {
+	if len(data) == 2 && data[1] == 255 {
+		*d = DPT_5001Auto{Auto: true}
+		return nil
 	}
 
-	// Check the value for valid range
-	if value < -273 {
-		return fmt.Errorf("Temperatur \"%.2f\" outside range [-273, 670760]", value)
-	} else if value > 670760 {
-		return fmt.Errorf("Temperatur \"%.2f\" outside range [-273, 670760]", value)
+	var value DPT_5001
+	if err := value.Unpack(data); err != nil {
+		return err
 	}
 
-	*d = DPT_9001(value)
+	*d = DPT_5001Auto{Value: value}
 
 	return nil
 }
 
-func (d DPT_9001) Unit() string {
-	return "°C"
+func (d DPT_5001Auto) Unit() string {
+	return "%"
 }
 
-func (d DPT_9001) String() string {
-	return fmt.Sprintf("%.2f °C", float32(d))
+func (d DPT_5001Auto) String() string {
+	if d.Auto {
+		return "Auto"
+	}
+
+	return d.Value.String()
 }
 
-// DPT_9004 represents DPT 9.004 / Illumination.
-type DPT_9004 float32
+// Position is a DPT_5001 blinds position. Gateways disagree on whether 0% means fully open or
+// fully closed; Invert swaps 0% and 100% on the bus for those which use the opposite convention of
+// the application.
+type Position struct {
+	Value  DPT_5001
+	Invert bool
+}
 
-func (d DPT_9004) Pack() []byte {
-	if d <= 0 {
-		return packF16(0)
-	} else if d >= 670760 {
-		return packF16(670760)
-	} else {
-		return packF16(float32(d))
+func (d Position) Pack() []byte {
+	if d.Invert {
+		return (100 - d.Value).Pack()
 	}
+
+	return d.Value.Pack()
 }
 
-func (d *DPT_9004) Unpack(data []byte) error {
-	var value float32
-	if err := unpackF16(data, &value); err != nil {
+// Unpack sets the value from the received percentage. Invert must be configured beforehand.
+func (d *Position) Unpack(data []byte) error {
+	var value DPT_5001
+	if err := value.Unpack(data); err != nil {
 		return err
 	}
 
-	// Check the value for valid range
-	if value < 0 {
-		return fmt.Errorf("Temperatur \"%.2f\" outside range [0, 670760]", value)
-	} else if value > 670760 {
-		return fmt.Errorf("Temperatur \"%.2f\" outside range [0, 670760]", value)
+	if d.Invert {
+		value = 100 - value
 	}
 
-	*d = DPT_9004(value)
+	d.Value = value
 
 	return nil
 }
 
-func (d DPT_9004) Unit() string {
-	return "lux"
+func (d Position) Unit() string {
+	return "%"
 }
 
-func (d DPT_9004) String() string {
-	return fmt.Sprintf("%.2f lux", float32(d))
+func (d Position) String() string {
+	return d.Value.String()
 }
 
-// DPT_12001 represents DPT 12.001 / Unsigned counter.
-type DPT_12001 uint32
-
-func (d DPT_12001) Pack() []byte {
-	return packU32(uint32(d))
+// TiltValue maps the physical tilt angle of cover slats onto a DPT_5001 percentage, e.g. an angle
+// of 0° to 90° onto 0% to 100%. Angles outside of [Min, Max] are clamped. Invert swaps 0% and
+// 100% for actuators with the opposite convention.
+type TiltValue struct {
+	Angle    float32
+	Min, Max float32
+	Invert   bool
 }
 
-func (d *DPT_12001) Unpack(data []byte) error {
-	return unpackU32(data, (*uint32)(d))
+// Percent returns the percentage which represents the angle.
+func (d TiltValue) Percent() DPT_5001 {
+	if d.Max <= d.Min {
+		return 0
+	}
+
+	percent := (d.Angle - d.Min) / (d.Max - d.Min) * 100
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
+	}
+
+	if d.Invert {
+		percent = 100 - percent
+	}
+
+	return DPT_5001(percent)
+}
+
+func (d TiltValue) Pack() []byte {
+	return d.Percent().Pack()
+}
+
+// Unpack sets the angle from the received percentage. Min, Max and Invert must be configured
+// beforehand.
+func (d *TiltValue) Unpack(data []byte) error {
+	var percent DPT_5001
+	if err := percent.Unpack(data); err != nil {
+		return err
+	}
+
+	if d.Invert {
+		percent = 100 - percent
+	}
+
+	d.Angle = d.Min + float32(percent)/100*(d.Max-d.Min)
+
+	return nil
+}
+
+func (d TiltValue) Unit() string {
+	return "°"
+}
+
+func (d TiltValue) String() string {
+	return fmt.Sprintf("%.2f°", d.Angle)
+}
+
+// DPT_5003 represents DPT 5.003 / Angle.
+//
+// Angles outside of [0, 360) are normalized when packing, e.g. 370° becomes 10° and -30° becomes
+// 330°.
+type DPT_5003 float32
+
+func (d DPT_5003) Pack() []byte {
+	return packU8(packAngleOctet(float64(d)))
+}
+
+func (d DPT_5003) AppendPack(dst []byte) []byte {
+	return appendU8(dst, packAngleOctet(float64(d)))
+}
+
+func (d *DPT_5003) Unpack(data []byte) error {
+	return unpackScaled(data, 360, (*float32)(d))
+}
+
+func (d DPT_5003) Unit() string {
+	return "°"
+}
+
+func (d DPT_5003) String() string {
+	return fmt.Sprintf("%.2f°", float32(d))
+}
+
+// Quantize returns the value as it is after a pack/unpack round trip, i.e. as it is transmitted.
+// The octet 255 stands for 360°, which is returned as the equivalent 0°.
+func (d DPT_5003) Quantize() DPT_5003 {
+	pack := func(f float32) []byte { return DPT_5003(f).Pack() }
+	unpack := func(data []byte, f *float32) error {
+		if err := unpackScaled(data, 360, f); err != nil || *f < 360 {
+			return err
+		}
+
+		*f = 0
+
+		return nil
+	}
+
+	return DPT_5003(quantize(float32(d), pack, unpack))
+}
+
+var compassPoints = []string{
+	"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
+
+// Compass returns the label of the nearest point of the 16-point compass rose, e.g. "NE" for 45°.
+func (d DPT_5003) Compass() string {
+	angle := math.Mod(float64(d), 360)
+	if angle < 0 {
+		angle += 360
+	}
+
+	return compassPoints[int(angle/22.5+0.5)%len(compassPoints)]
+}
+
+// Lerp interpolates between two angles along the shorter arc, e.g. from 350° to 10° it passes 0°
+// rather than 180°. t is the position between from (0) and to (1). The result is normalized to
+// [0°, 360°). If both arcs have the same length, the angle increases.
+func Lerp(from, to DPT_5003, t float32) DPT_5003 {
+	delta := math.Mod(float64(to)-float64(from), 360)
+	if delta <= -180 {
+		delta += 360
+	} else if delta > 180 {
+		delta -= 360
+	}
+
+	angle := math.Mod(float64(from)+delta*float64(t), 360)
+	if angle < 0 {
+		angle += 360
+	}
+
+	return DPT_5003(angle)
+}
+
+// DPT_5004 represents DPT 5.004 / Percent_U8.
+//
+// Unlike DPT_5001, which scales 0-100% onto the octet, the value of this type ranges from 0% to
+// 255% and is identical to the raw octet. Converting it to uint8 yields the raw octet and no
+// quantization takes place.
+type DPT_5004 uint8
+
+func (d DPT_5004) Pack() []byte {
+	return packU8(uint8(d))
+}
+
+func (d DPT_5004) AppendPack(dst []byte) []byte {
+	return appendU8(dst, uint8(d))
+}
+
+func (d *DPT_5004) Unpack(data []byte) error {
+	return unpackU8(data, (*uint8)(d))
+}
+
+func (d DPT_5004) Unit() string {
+	return "%"
+}
+
+func (d DPT_5004) String() string {
+	return fmt.Sprintf("%.2f%%", float32(d))
+}
+
+// durationToU16 converts a duration into a count of the given resolution, clamped to the range
+// of an unsigned 16-bit integer.
+func durationToU16(duration, resolution time.Duration) uint16 {
+	count := duration / resolution
+
+	if count < 0 {
+		return 0
+	} else if count > math.MaxUint16 {
+		return math.MaxUint16
+	}
+
+	return uint16(count)
+}
+
+// DPT_7002 represents DPT 7.002 / Time period (ms).
+type DPT_7002 uint16
+
+// NewDPT_7002FromDuration creates a DPT_7002 from a duration. The duration is truncated to the
+// resolution of the type and clamped to its range.
+func NewDPT_7002FromDuration(duration time.Duration) DPT_7002 {
+	return DPT_7002(durationToU16(duration, time.Millisecond))
+}
+
+func (d DPT_7002) Pack() []byte {
+	return packU16(uint16(d))
+}
+
+func (d DPT_7002) AppendPack(dst []byte) []byte {
+	return appendU16(dst, uint16(d))
+}
+
+func (d *DPT_7002) Unpack(data []byte) error {
+	return unpackU16(data, (*uint16)(d))
+}
+
+func (d DPT_7002) Unit() string {
+	return "ms"
+}
+
+func (d DPT_7002) String() string {
+	return fmt.Sprintf("%d ms", uint16(d))
+}
+
+// Duration returns the time period as a duration.
+func (d DPT_7002) Duration() time.Duration {
+	return time.Duration(d) * time.Millisecond
+}
+
+// DPT_7003 represents DPT 7.003 / Time period (10 ms).
+type DPT_7003 uint16
+
+// NewDPT_7003FromDuration creates a DPT_7003 from a duration. The duration is truncated to the
+// resolution of the type and clamped to its range.
+func NewDPT_7003FromDuration(duration time.Duration) DPT_7003 {
+	return DPT_7003(durationToU16(duration, 10*time.Millisecond))
+}
+
+func (d DPT_7003) Pack() []byte {
+	return packU16(uint16(d))
+}
+
+func (d DPT_7003) AppendPack(dst []byte) []byte {
+	return appendU16(dst, uint16(d))
+}
+
+func (d *DPT_7003) Unpack(data []byte) error {
+	return unpackU16(data, (*uint16)(d))
+}
+
+func (d DPT_7003) Unit() string {
+	return "ms"
+}
+
+func (d DPT_7003) String() string {
+	return fmt.Sprintf("%d ms", uint32(d)*10)
+}
+
+// Duration returns the time period as a duration.
+func (d DPT_7003) Duration() time.Duration {
+	return time.Duration(d) * 10 * time.Millisecond
+}
+
+// DPT_7004 represents DPT 7.004 / Time period (100 ms).
+type DPT_7004 uint16
+
+// NewDPT_7004FromDuration creates a DPT_7004 from a duration. The duration is truncated to the
+// resolution of the type and clamped to its range.
+func NewDPT_7004FromDuration(duration time.Duration) DPT_7004 {
+	return DPT_7004(durationToU16(duration, 100*time.Millisecond))
+}
+
+func (d DPT_7004) Pack() []byte {
+	return packU16(uint16(d))
+}
+
+func (d DPT_7004) AppendPack(dst []byte) []byte {
+	return appendU16(dst, uint16(d))
+}
+
+func (d *DPT_7004) Unpack(data []byte) error {
+	return unpackU16(data, (*uint16)(d))
+}
+
+func (d DPT_7004) Unit() string {
+	return "ms"
+}
+
+func (d DPT_7004) String() string {
+	return fmt.Sprintf("%d ms", uint32(d)*100)
+}
+
+// Duration returns the time period as a duration.
+func (d DPT_7004) Duration() time.Duration {
+	return time.Duration(d) * 100 * time.Millisecond
+}
+
+// DPT_7010 represents DPT 7.010 / Property data type.
+type DPT_7010 uint16
+
+func (d DPT_7010) Pack() []byte {
+	return packU16(uint16(d))
+}
+
+func (d DPT_7010) AppendPack(dst []byte) []byte {
+	return appendU16(dst, uint16(d))
+}
+
+func (d *DPT_7010) Unpack(data []byte) error {
+	return unpackU16(data, (*uint16)(d))
+}
+
+func (d DPT_7010) Unit() string {
+	return ""
+}
+
+func (d DPT_7010) String() string {
+	return fmt.Sprintf("%d", uint16(d))
+}
+
+// DPT_7011 represents DPT 7.011 / Length.
+type DPT_7011 uint16
+
+func (d DPT_7011) Pack() []byte {
+	return packU16(uint16(d))
+}
+
+func (d DPT_7011) AppendPack(dst []byte) []byte {
+	return appendU16(dst, uint16(d))
+}
+
+func (d *DPT_7011) Unpack(data []byte) error {
+	return unpackU16(data, (*uint16)(d))
+}
+
+func (d DPT_7011) Unit() string {
+	return "mm"
+}
+
+func (d DPT_7011) String() string {
+	return fmt.Sprintf("%d mm", uint16(d))
+}
+
+// DPT_7012 represents DPT 7.012 / Current.
+type DPT_7012 uint16
+
+func (d DPT_7012) Pack() []byte {
+	return packU16(uint16(d))
+}
+
+func (d DPT_7012) AppendPack(dst []byte) []byte {
+	return appendU16(dst, uint16(d))
+}
+
+func (d *DPT_7012) Unpack(data []byte) error {
+	return unpackU16(data, (*uint16)(d))
+}
+
+func (d DPT_7012) Unit() string {
+	return "mA"
+}
+
+func (d DPT_7012) String() string {
+	return fmt.Sprintf("%d mA", uint16(d))
+}
+
+// DPT_7013 represents DPT 7.013 / Brightness.
+type DPT_7013 uint16
+
+func (d DPT_7013) Pack() []byte {
+	return packU16(uint16(d))
+}
+
+func (d DPT_7013) AppendPack(dst []byte) []byte {
+	return appendU16(dst, uint16(d))
+}
+
+func (d *DPT_7013) Unpack(data []byte) error {
+	return unpackU16(data, (*uint16)(d))
+}
+
+func (d DPT_7013) Unit() string {
+	return "lx"
+}
+
+func (d DPT_7013) String() string {
+	return fmt.Sprintf("%d lx", uint16(d))
+}
+
+// To9004 converts the illuminance to DPT_9004.
+func (d DPT_7013) To9004() DPT_9004 {
+	return DPT_9004(d)
+}
+
+// DPT_8002 represents DPT 8.002 / Delta time (ms).
+type DPT_8002 int16
+
+// NewDPT_8002FromDuration creates a DPT_8002 from a duration. The duration is truncated to
+// milliseconds and clamped to the range of the type.
+func NewDPT_8002FromDuration(duration time.Duration) DPT_8002 {
+	ms := duration / time.Millisecond
+
+	if ms < math.MinInt16 {
+		return math.MinInt16
+	} else if ms > math.MaxInt16 {
+		return math.MaxInt16
+	}
+
+	return DPT_8002(ms)
+}
+
+func (d DPT_8002) Pack() []byte {
+	return packV16(int16(d))
+}
+
+func (d DPT_8002) AppendPack(dst []byte) []byte {
+	return appendV16(dst, int16(d))
+}
+
+func (d *DPT_8002) Unpack(data []byte) error {
+	return unpackV16(data, (*int16)(d))
+}
+
+func (d DPT_8002) Unit() string {
+	return "ms"
+}
+
+func (d DPT_8002) String() string {
+	return fmt.Sprintf("%d ms", int16(d))
+}
+
+// Duration returns the delta time as a duration.
+func (d DPT_8002) Duration() time.Duration {
+	return time.Duration(d) * time.Millisecond
+}
+
+// DPT_9001 represents DPT 9.001 / Temperature.
+//
+// NaN represents the invalid value, which is transmitted as 0x7FFF.
+type DPT_9001 float32
+
+func (d DPT_9001) Pack() []byte {
+	return d.AppendPack(make([]byte, 0, 3))
+}
+
+func (d DPT_9001) AppendPack(dst []byte) []byte {
+	return appendF16(dst, temperatureRange.clamp(float32(d)))
+}
+
+func (d *DPT_9001) Unpack(data []byte) error {
+	var value float32
+	if err := unpackF16(data, &value); err != nil {
+		return err
+	}
+
+	// Check the value for valid range
+	if value < -273 {
+		return fmt.Errorf("Temperatur \"%.2f\" outside range [-273, 670760]", value)
+	} else if value > 670760 {
+		return fmt.Errorf("Temperatur \"%.2f\" outside range [-273, 670760]", value)
+	}
+
+	*d = DPT_9001(value)
+
+	return nil
+}
+
+// UnpackWithStatus unpacks the value like Unpack, but also accepts frames with a trailing status
+// octet. It reports whether the status octet flags a sensor fault.
+func (d *DPT_9001) UnpackWithStatus(data []byte) (fault bool, err error) {
+	return unpackF16WithStatus(data, d)
+}
+
+func (d DPT_9001) Unit() string {
+	return "°C"
+}
+
+func (d DPT_9001) String() string {
+	return fmt.Sprintf("%.2f °C", float32(d))
+}
+
+// Quantize returns the value as it is after a pack/unpack round trip, i.e. as it is transmitted.
+func (d DPT_9001) Quantize() DPT_9001 {
+	return DPT_9001(quantizeF16(temperatureRange.clamp(float32(d))))
+}
+
+// Add returns the sum of the value and delta, rounded to the nearest value that can be
+// transmitted. Sums outside of the range of the type are clamped like when packing. Repeated
+// arithmetic thus stays on values that can be transmitted.
+func (d DPT_9001) Add(delta float32) DPT_9001 {
+	return DPT_9001(roundF16(temperatureRange.clamp(float32(d) + delta)))
+}
+
+// Sub returns the difference of the value and delta, rounded like Add.
+func (d DPT_9001) Sub(delta float32) DPT_9001 {
+	return DPT_9001(roundF16(temperatureRange.clamp(float32(d) - delta)))
+}
+
+// Format implements fmt.Formatter. %v prints the plain value, %+v includes the unit and datapoint
+// type.
+func (d DPT_9001) Format(f fmt.State, verb rune) {
+	formatValue(f, verb, float32(d), fmt.Sprintf("%.2f", float32(d)), d.String(), "9.001")
+}
+
+// Valid determines whether the value is not the invalid value.
+func (d DPT_9001) Valid() bool {
+	return d == d
+}
+
+// Float64 returns the value as it is encoded on the bus. It is decoded from mantissa and exponent
+// directly into a float64, without rounding to float32 in between.
+func (d DPT_9001) Float64() float64 {
+	var f float64
+	unpackF16Float64(d.Pack(), &f)
+	return f
+}
+
+// Less reports whether d is lower than other.
+func (d DPT_9001) Less(other DPT_9001) bool {
+	return d < other
+}
+
+// DPT_9001Slice attaches the methods of sort.Interface to []DPT_9001, sorting in increasing order.
+type DPT_9001Slice []DPT_9001
+
+func (s DPT_9001Slice) Len() int           { return len(s) }
+func (s DPT_9001Slice) Less(i, j int) bool { return s[i].Less(s[j]) }
+func (s DPT_9001Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// Shift returns the setpoint d shifted by the given temperature difference. The result is
+// re-encoded through the 2-octet float format.
+func (d DPT_9001) Shift(shift DPT_9002) DPT_9001 {
+	return DPT_9001(quantizeF16(float32(d) + float32(shift)))
+}
+
+// Delta returns the difference from prev to cur as a temperature difference, the inverse of
+// Shift. The result is re-encoded through the 2-octet float format. If either value is invalid,
+// the result is invalid.
+func Delta(prev, cur DPT_9001) DPT_9002 {
+	return DPT_9002(quantizeF16(float32(cur) - float32(prev)))
+}
+
+// NewDPT_9001FromKelvin creates a DPT_9001 from a temperature in Kelvin. The value is stored in
+// degrees Celsius like any other DPT_9001.
+func NewDPT_9001FromKelvin(k float32) DPT_9001 {
+	return DPT_9001(k - 273.15)
+}
+
+// Kelvin returns the temperature in Kelvin.
+func (d DPT_9001) Kelvin() float32 {
+	return float32(d) + 273.15
+}
+
+var comfortBands = []string{"cold", "cool", "comfortable", "warm", "hot"}
+
+// ComfortBand classifies the temperature as "cold", "cool", "comfortable", "warm" or "hot". The
+// four thresholds in ascending order are the lower bounds of the bands from "cool" upwards, e.g.
+// {16, 20, 24, 28}. The invalid value and a wrong number of thresholds yield an empty string.
+func (d DPT_9001) ComfortBand(thresholds []float32) string {
+	if !d.Valid() || len(thresholds) != len(comfortBands)-1 {
+		return ""
+	}
+
+	band := 0
+	for _, threshold := range thresholds {
+		if float32(d) >= threshold {
+			band++
+		}
+	}
+
+	return comfortBands[band]
+}
+
+// ClampedDPT_9001 is a DPT_9001 with a range that is specific to a device, e.g. a sensor which only
+// reports 15 °C to 30 °C. Both limits must be set.
+type ClampedDPT_9001 struct {
+	Value DPT_9001
+	Min   DPT_9001
+	Max   DPT_9001
+
+	// OutOfRange is set by Unpack if the received value lies outside of [Min, Max].
+	OutOfRange bool
+}
+
+// Pack packs the value after clamping it to [Min, Max]. The invalid value is packed as it is.
+func (d ClampedDPT_9001) Pack() []byte {
+	value := d.Value
+	if value < d.Min {
+		value = d.Min
+	} else if value > d.Max {
+		value = d.Max
+	}
+
+	return value.Pack()
+}
+
+// Unpack unpacks the value as it is and flags it if it lies outside of [Min, Max]. The invalid
+// value is not flagged.
+func (d *ClampedDPT_9001) Unpack(data []byte) error {
+	var value DPT_9001
+	if err := value.Unpack(data); err != nil {
+		return err
+	}
+
+	d.Value = value
+	d.OutOfRange = value < d.Min || value > d.Max
+
+	return nil
+}
+
+func (d ClampedDPT_9001) Unit() string {
+	return d.Value.Unit()
+}
+
+func (d ClampedDPT_9001) String() string {
+	return d.Value.String()
+}
+
+// DPT_9002 represents DPT 9.002 / Temperature difference.
+//
+// NaN represents the invalid value, which is transmitted as 0x7FFF.
+type DPT_9002 float32
+
+func (d DPT_9002) Pack() []byte {
+	return d.AppendPack(make([]byte, 0, 3))
+}
+
+func (d DPT_9002) AppendPack(dst []byte) []byte {
+	return appendF16(dst, f16Range.clamp(float32(d)))
+}
+
+func (d *DPT_9002) Unpack(data []byte) error {
+	var value float32
+	if err := unpackF16(data, &value); err != nil {
+		return err
+	}
+
+	// Check the value for valid range
+	if value < -670760 || value > 670760 {
+		return fmt.Errorf("Temperature difference \"%.2f\" outside range [-670760, 670760]", value)
+	}
+
+	*d = DPT_9002(value)
+
+	return nil
+}
+
+// UnpackWithStatus unpacks the value like Unpack, but also accepts frames with a trailing status
+// octet. It reports whether the status octet flags a sensor fault.
+func (d *DPT_9002) UnpackWithStatus(data []byte) (fault bool, err error) {
+	return unpackF16WithStatus(data, d)
+}
+
+func (d DPT_9002) Unit() string {
+	return "K"
+}
+
+func (d DPT_9002) String() string {
+	return fmt.Sprintf("%.2f K", float32(d))
+}
+
+// Quantize returns the value as it is after a pack/unpack round trip, i.e. as it is transmitted.
+func (d DPT_9002) Quantize() DPT_9002 {
+	return DPT_9002(quantizeF16(f16Range.clamp(float32(d))))
+}
+
+// Add returns the sum of the value and delta, rounded to the nearest value that can be
+// transmitted. Sums outside of the range of the type are clamped like when packing. Repeated
+// arithmetic thus stays on values that can be transmitted.
+func (d DPT_9002) Add(delta float32) DPT_9002 {
+	return DPT_9002(roundF16(f16Range.clamp(float32(d) + delta)))
+}
+
+// Sub returns the difference of the value and delta, rounded like Add.
+func (d DPT_9002) Sub(delta float32) DPT_9002 {
+	return DPT_9002(roundF16(f16Range.clamp(float32(d) - delta)))
+}
+
+// Format implements fmt.Formatter. %v prints the plain value, %+v includes the unit and datapoint
+// type.
+func (d DPT_9002) Format(f fmt.State, verb rune) {
+	formatValue(f, verb, float32(d), fmt.Sprintf("%.2f", float32(d)), d.String(), "9.002")
+}
+
+// Valid determines whether the value is not the invalid value.
+func (d DPT_9002) Valid() bool {
+	return d == d
+}
+
+// Float64 returns the value as it is encoded on the bus. It is decoded from mantissa and exponent
+// directly into a float64, without rounding to float32 in between.
+func (d DPT_9002) Float64() float64 {
+	var f float64
+	unpackF16Float64(d.Pack(), &f)
+	return f
+}
+
+// DPT_9004 represents DPT 9.004 / Illumination.
+//
+// NaN represents the invalid value, which is transmitted as 0x7FFF.
+type DPT_9004 float32
+
+func (d DPT_9004) Pack() []byte {
+	return d.AppendPack(make([]byte, 0, 3))
+}
+
+func (d DPT_9004) AppendPack(dst []byte) []byte {
+	return appendF16(dst, f16PositiveRange.clamp(float32(d)))
+}
+
+func (d *DPT_9004) Unpack(data []byte) error {
+	var value float32
+	if err := unpackF16(data, &value); err != nil {
+		return err
+	}
+
+	// Check the value for valid range
+	if value < 0 {
+		return fmt.Errorf("Temperatur \"%.2f\" outside range [0, 670760]", value)
+	} else if value > 670760 {
+		return fmt.Errorf("Temperatur \"%.2f\" outside range [0, 670760]", value)
+	}
+
+	*d = DPT_9004(value)
+
+	return nil
+}
+
+// UnpackWithStatus unpacks the value like Unpack, but also accepts frames with a trailing status
+// octet. It reports whether the status octet flags a sensor fault.
+func (d *DPT_9004) UnpackWithStatus(data []byte) (fault bool, err error) {
+	return unpackF16WithStatus(data, d)
+}
+
+func (d DPT_9004) Unit() string {
+	return "lx"
+}
+
+func (d DPT_9004) String() string {
+	return fmt.Sprintf("%.2f lx", float32(d))
+}
+
+// Quantize returns the value as it is after a pack/unpack round trip, i.e. as it is transmitted.
+func (d DPT_9004) Quantize() DPT_9004 {
+	return DPT_9004(quantizeF16(f16PositiveRange.clamp(float32(d))))
+}
+
+// Add returns the sum of the value and delta, rounded to the nearest value that can be
+// transmitted. Sums outside of the range of the type are clamped like when packing. Repeated
+// arithmetic thus stays on values that can be transmitted.
+func (d DPT_9004) Add(delta float32) DPT_9004 {
+	return DPT_9004(roundF16(f16PositiveRange.clamp(float32(d) + delta)))
+}
+
+// Sub returns the difference of the value and delta, rounded like Add.
+func (d DPT_9004) Sub(delta float32) DPT_9004 {
+	return DPT_9004(roundF16(f16PositiveRange.clamp(float32(d) - delta)))
+}
+
+// Format implements fmt.Formatter. %v prints the plain value, %+v includes the unit and datapoint
+// type.
+func (d DPT_9004) Format(f fmt.State, verb rune) {
+	formatValue(f, verb, float32(d), fmt.Sprintf("%.2f", float32(d)), d.String(), "9.004")
+}
+
+// Valid determines whether the value is not the invalid value.
+func (d DPT_9004) Valid() bool {
+	return d == d
+}
+
+// Float64 returns the value as it is encoded on the bus. It is decoded from mantissa and exponent
+// directly into a float64, without rounding to float32 in between.
+func (d DPT_9004) Float64() float64 {
+	var f float64
+	unpackF16Float64(d.Pack(), &f)
+	return f
+}
+
+// ExposureValue converts the illuminance to an exposure value (EV at ISO 100) using
+// EV = log2(E / 2.5), where E is the illuminance in lux.
+func (d DPT_9004) ExposureValue() float32 {
+	return float32(math.Log2(float64(d) / 2.5))
+}
+
+// To7013 converts the illuminance to DPT_7013, rounded to whole lux. Values outside of the range
+// of DPT_7013 are clamped, the invalid value yields 0.
+func (d DPT_9004) To7013() DPT_7013 {
+	if !d.Valid() || d <= 0 {
+		return 0
+	} else if d >= math.MaxUint16 {
+		return math.MaxUint16
+	}
+
+	return DPT_7013(math.Floor(float64(d) + 0.5))
+}
+
+// DPT_9007 represents DPT 9.007 / Humidity.
+//
+// NaN represents the invalid value, which is transmitted as 0x7FFF.
+type DPT_9007 float32
+
+func (d DPT_9007) Pack() []byte {
+	return d.AppendPack(make([]byte, 0, 3))
+}
+
+func (d DPT_9007) AppendPack(dst []byte) []byte {
+	return appendF16(dst, f16PositiveRange.clamp(float32(d)))
+}
+
+func (d *DPT_9007) Unpack(data []byte) error {
+	var value float32
+	if err := unpackF16(data, &value); err != nil {
+		return err
+	}
+
+	// Check the value for valid range
+	if value < 0 || value > 670760 {
+		return fmt.Errorf("Humidity \"%.2f\" outside range [0, 670760]", value)
+	}
+
+	*d = DPT_9007(value)
+
+	return nil
+}
+
+// UnpackWithStatus unpacks the value like Unpack, but also accepts frames with a trailing status
+// octet. It reports whether the status octet flags a sensor fault.
+func (d *DPT_9007) UnpackWithStatus(data []byte) (fault bool, err error) {
+	return unpackF16WithStatus(data, d)
+}
+
+func (d DPT_9007) Unit() string {
+	return "%"
+}
+
+func (d DPT_9007) String() string {
+	return fmt.Sprintf("%.2f%%", float32(d))
+}
+
+// Quantize returns the value as it is after a pack/unpack round trip, i.e. as it is transmitted.
+func (d DPT_9007) Quantize() DPT_9007 {
+	return DPT_9007(quantizeF16(f16PositiveRange.clamp(float32(d))))
+}
+
+// Add returns the sum of the value and delta, rounded to the nearest value that can be
+// transmitted. Sums outside of the range of the type are clamped like when packing. Repeated
+// arithmetic thus stays on values that can be transmitted.
+func (d DPT_9007) Add(delta float32) DPT_9007 {
+	return DPT_9007(roundF16(f16PositiveRange.clamp(float32(d) + delta)))
+}
+
+// Sub returns the difference of the value and delta, rounded like Add.
+func (d DPT_9007) Sub(delta float32) DPT_9007 {
+	return DPT_9007(roundF16(f16PositiveRange.clamp(float32(d) - delta)))
+}
+
+// Format implements fmt.Formatter. %v prints the plain value, %+v includes the unit and datapoint
+// type.
+func (d DPT_9007) Format(f fmt.State, verb rune) {
+	formatValue(f, verb, float32(d), fmt.Sprintf("%.2f", float32(d)), d.String(), "9.007")
+}
+
+// Valid determines whether the value is not the invalid value.
+func (d DPT_9007) Valid() bool {
+	return d == d
+}
+
+// Float64 returns the value as it is encoded on the bus. It is decoded from mantissa and exponent
+// directly into a float64, without rounding to float32 in between.
+func (d DPT_9007) Float64() float64 {
+	var f float64
+	unpackF16Float64(d.Pack(), &f)
+	return f
+}
+
+// DPT_10001 represents DPT 10.001 / Time of day.
+//
+// Weekday ranges from 1 (Monday) to 7 (Sunday); 0 means that no day is given.
+type DPT_10001 struct {
+	Weekday uint8
+	Hour    uint8
+	Minutes uint8
+	Seconds uint8
+}
+
+func (d DPT_10001) Pack() []byte {
+	return []byte{0, (d.Weekday&7)<<5 | d.Hour&31, d.Minutes & 63, d.Seconds & 63}
+}
+
+func (d *DPT_10001) Unpack(data []byte) error {
+	if len(data) != 4 {
+		return ErrInvalidLength
+	}
+
+	value := DPT_10001{
+		Weekday: data[1] >> 5,
+		Hour:    data[1] & 31,
+		Minutes: data[2] & 63,
+		Seconds: data[3] & 63,
+	}
+
+	if value.Hour > 23 || value.Minutes > 59 || value.Seconds > 59 {
+		return fmt.Errorf("Time of day \"%s\" is invalid", value)
+	}
+
+	*d = value
+
+	return nil
+}
+
+func (d DPT_10001) Unit() string {
+	return ""
+}
+
+func (d DPT_10001) String() string {
+	return fmt.Sprintf("%02d:%02d:%02d", d.Hour, d.Minutes, d.Seconds)
+}
+
+// sinceMidnight returns the time that has passed since midnight.
+func (d DPT_10001) sinceMidnight() time.Duration {
+	return time.Duration(d.Hour)*time.Hour +
+		time.Duration(d.Minutes)*time.Minute +
+		time.Duration(d.Seconds)*time.Second
+}
+
+// Sub returns the duration d - other. Both times are taken to be on the same day; the weekday is
+// ignored.
+func (d DPT_10001) Sub(other DPT_10001) time.Duration {
+	return d.sinceMidnight() - other.sinceMidnight()
+}
+
+// Before reports whether d is earlier in the day than other. The weekday is ignored.
+func (d DPT_10001) Before(other DPT_10001) bool {
+	return d.sinceMidnight() < other.sinceMidnight()
+}
+
+// After reports whether d is later in the day than other. The weekday is ignored.
+func (d DPT_10001) After(other DPT_10001) bool {
+	return d.sinceMidnight() > other.sinceMidnight()
+}
+
+// DPT_12001 represents DPT 12.001 / Unsigned counter.
+type DPT_12001 uint32
+
+func (d DPT_12001) Pack() []byte {
+	return packU32(uint32(d))
+}
+
+func (d DPT_12001) AppendPack(dst []byte) []byte {
+	return appendU32(dst, uint32(d))
+}
+
+func (d *DPT_12001) Unpack(data []byte) error {
+	return unpackU32(data, (*uint32)(d))
+}
+
+func (d DPT_12001) Unit() string {
+	return "pulses"
+}
+
+func (d DPT_12001) String() string {
+	return fmt.Sprintf("%d pulses", uint32(d))
+}
+
+// HumanString formats the value with an SI prefix and its unit, e.g. "1.5M pulses".
+func (d DPT_12001) HumanString() string {
+	return humanCount(float64(d)) + " " + d.Unit()
+}
+
+// Inc returns the counter incremented by n. Like a hardware counter, it wraps around to 0 after
+// reaching the maximum value.
+func (d DPT_12001) Inc(n uint32) DPT_12001 {
+	return d + DPT_12001(n)
+}
+
+// DPT_13001 represents DPT 13.001 / counter value.
+type DPT_13001 int32
+
+func (d DPT_13001) Pack() []byte {
+	return packV32(int32(d))
+}
+
+func (d DPT_13001) AppendPack(dst []byte) []byte {
+	return appendV32(dst, int32(d))
+}
+
+func (d *DPT_13001) Unpack(data []byte) error {
+	return unpackV32(data, (*int32)(d))
+}
+
+func (d DPT_13001) Unit() string {
+	return "pulses"
+}
+
+func (d DPT_13001) String() string {
+	return fmt.Sprintf("%d pulses", int32(d))
+}
+
+// HumanString formats the value with an SI prefix and its unit, e.g. "1.5M pulses".
+func (d DPT_13001) HumanString() string {
+	return humanCount(float64(d)) + " " + d.Unit()
+}
+
+// AddDelta computes the number of pulses counted between the readings prev and cur. A counter
+// that crosses the boundary of its 32-bit range wraps around; in that case the delta is computed
+// across the boundary and wrapped is true.
+func AddDelta(prev, cur DPT_13001) (delta int64, wrapped bool) {
+	delta = int64(cur) - int64(prev)
+
+	if delta > math.MaxInt32 {
+		return delta - 1<<32, true
+	} else if delta < math.MinInt32 {
+		return delta + 1<<32, true
+	}
+
+	return delta, false
+}
+
+// DPT_13002 represents DPT 13.002 / flow rate.
+type DPT_13002 int32
+
+func (d DPT_13002) Pack() []byte {
+	return packV32(int32(d))
+}
+
+func (d DPT_13002) AppendPack(dst []byte) []byte {
+	return appendV32(dst, int32(d))
+}
+
+func (d *DPT_13002) Unpack(data []byte) error {
+	return unpackV32(data, (*int32)(d))
+}
+
+func (d DPT_13002) Unit() string {
+	return "m^3/h"
+}
+
+func (d DPT_13002) String() string {
+	return fmt.Sprintf("%d m^3/h", int32(d))
+}
+
+// HumanString formats the value with an SI prefix and its unit, e.g. "1.5M m^3/h".
+func (d DPT_13002) HumanString() string {
+	return humanCount(float64(d)) + " " + d.Unit()
+}
+
+// DPT_13010 represents DPT 13.010 / active energy.
+type DPT_13010 int32
+
+func (d DPT_13010) Pack() []byte {
+	return packV32(int32(d))
+}
+
+func (d DPT_13010) AppendPack(dst []byte) []byte {
+	return appendV32(dst, int32(d))
+}
+
+func (d *DPT_13010) Unpack(data []byte) error {
+	return unpackV32(data, (*int32)(d))
+}
+
+func (d DPT_13010) Unit() string {
+	return "Wh"
+}
+
+func (d DPT_13010) String() string {
+	return fmt.Sprintf("%d Wh", int32(d))
+}
+
+// HumanString formats the value with an SI prefix and its unit, e.g. "1.5M Wh".
+func (d DPT_13010) HumanString() string {
+	return humanCount(float64(d)) + " " + d.Unit()
+}
+
+// RoundMode selects how values are rounded when converting between units.
+type RoundMode int
+
+const (
+	// RoundNearest rounds to the nearest integer, with halves rounded away from zero.
+	RoundNearest RoundMode = iota
+
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+
+	// RoundCeil rounds towards positive infinity.
+	RoundCeil
+)
+
+// round rounds f to an integer using the given mode.
+func (mode RoundMode) round(f float64) float64 {
+	switch mode {
+	case RoundFloor:
+		return math.Floor(f)
+	case RoundCeil:
+		return math.Ceil(f)
+	default:
+		// math.Round is only available since Go 1.10.
+		if f < 0 {
+			return -math.Floor(-f + 0.5)
+		}
+
+		return math.Floor(f + 0.5)
+	}
+}
+
+// KilowattHours converts the energy to whole kilowatt hours, rounded using the given mode. This
+// matches the way utility statements bill consumption.
+func (d DPT_13010) KilowattHours(mode RoundMode) float64 {
+	return mode.round(float64(d) / 1000)
+}
+
+// DPT_13011 represents DPT 13.011 / apparant energy.
+type DPT_13011 int32
+
+func (d DPT_13011) Pack() []byte {
+	return packV32(int32(d))
+}
+
+func (d DPT_13011) AppendPack(dst []byte) []byte {
+	return appendV32(dst, int32(d))
+}
+
+func (d *DPT_13011) Unpack(data []byte) error {
+	return unpackV32(data, (*int32)(d))
+}
+
+func (d DPT_13011) Unit() string {
+	return "VAh"
+}
+
+func (d DPT_13011) String() string {
+	return fmt.Sprintf("%d VAh", int32(d))
+}
+
+// HumanString formats the value with an SI prefix and its unit, e.g. "1.5M VAh".
+func (d DPT_13011) HumanString() string {
+	return humanCount(float64(d)) + " " + d.Unit()
+}
+
+// DPT_13012 represents DPT 13.012 / reactive energy.
+type DPT_13012 int32
+
+func (d DPT_13012) Pack() []byte {
+	return packV32(int32(d))
+}
+
+func (d DPT_13012) AppendPack(dst []byte) []byte {
+	return appendV32(dst, int32(d))
+}
+
+func (d *DPT_13012) Unpack(data []byte) error {
+	return unpackV32(data, (*int32)(d))
+}
+
+func (d DPT_13012) Unit() string {
+	return "VARh"
+}
+
+func (d DPT_13012) String() string {
+	return fmt.Sprintf("%d VARh", int32(d))
+}
+
+// HumanString formats the value with an SI prefix and its unit, e.g. "1.5M VARh".
+func (d DPT_13012) HumanString() string {
+	return humanCount(float64(d)) + " " + d.Unit()
+}
+
+// DPT_13013 represents DPT 13.010 / active energy (kWh).
+type DPT_13013 int32
+
+func (d DPT_13013) Pack() []byte {
+	return packV32(int32(d))
+}
+
+func (d DPT_13013) AppendPack(dst []byte) []byte {
+	return appendV32(dst, int32(d))
+}
+
+func (d *DPT_13013) Unpack(data []byte) error {
+	return unpackV32(data, (*int32)(d))
+}
+
+func (d DPT_13013) Unit() string {
+	return "kWh"
+}
+
+func (d DPT_13013) String() string {
+	return fmt.Sprintf("%d kWh", int32(d))
+}
+
+// HumanString formats the value with an SI prefix and its unit, e.g. "1.5M kWh".
+func (d DPT_13013) HumanString() string {
+	return humanCount(float64(d)) + " " + d.Unit()
+}
+
+// Cost returns the cost of the energy at the given rate per kilowatt hour.
+func (d DPT_13013) Cost(ratePerKWh float64) float64 {
+	return float64(d) * ratePerKWh
+}
+
+// DPT_13014 represents DPT 13.014 / apparant energy (kVAh).
+type DPT_13014 int32
+
+func (d DPT_13014) Pack() []byte {
+	return packV32(int32(d))
+}
+
+func (d DPT_13014) AppendPack(dst []byte) []byte {
+	return appendV32(dst, int32(d))
+}
+
+func (d *DPT_13014) Unpack(data []byte) error {
+	return unpackV32(data, (*int32)(d))
+}
+
+func (d DPT_13014) Unit() string {
+	return "kVAh"
+}
+
+func (d DPT_13014) String() string {
+	return fmt.Sprintf("%d kVAh", int32(d))
+}
+
+// HumanString formats the value with an SI prefix and its unit, e.g. "1.5M kVAh".
+func (d DPT_13014) HumanString() string {
+	return humanCount(float64(d)) + " " + d.Unit()
+}
+
+// DPT_13015 represents DPT 13.015 / reactive energy (kVARh).
+type DPT_13015 int32
+
+func (d DPT_13015) Pack() []byte {
+	return packV32(int32(d))
+}
+
+func (d DPT_13015) AppendPack(dst []byte) []byte {
+	return appendV32(dst, int32(d))
+}
+
+func (d *DPT_13015) Unpack(data []byte) error {
+	return unpackV32(data, (*int32)(d))
+}
+
+func (d DPT_13015) Unit() string {
+	return "kVARh"
+}
+
+func (d DPT_13015) String() string {
+	return fmt.Sprintf("%d kVARh", int32(d))
+}
+
+// HumanString formats the value with an SI prefix and its unit, e.g. "1.5M kVARh".
+func (d DPT_13015) HumanString() string {
+	return humanCount(float64(d)) + " " + d.Unit()
+}
+
+// DPT_13100 represents DPT 13.100 / Delta time (s).
+type DPT_13100 int32
+
+// NewDPT_13100FromDuration creates a DPT_13100 from a duration. The duration is truncated to
+// seconds and clamped to the range of the type.
+func NewDPT_13100FromDuration(duration time.Duration) DPT_13100 {
+	s := duration / time.Second
+
+	if s < math.MinInt32 {
+		return math.MinInt32
+	} else if s > math.MaxInt32 {
+		return math.MaxInt32
+	}
+
+	return DPT_13100(s)
+}
+
+func (d DPT_13100) Pack() []byte {
+	return packV32(int32(d))
+}
+
+func (d DPT_13100) AppendPack(dst []byte) []byte {
+	return appendV32(dst, int32(d))
+}
+
+func (d *DPT_13100) Unpack(data []byte) error {
+	return unpackV32(data, (*int32)(d))
+}
+
+func (d DPT_13100) Unit() string {
+	return "s"
+}
+
+func (d DPT_13100) String() string {
+	return fmt.Sprintf("%d s", int32(d))
+}
+
+// HumanString formats the value with an SI prefix and its unit, e.g. "1.5M s".
+func (d DPT_13100) HumanString() string {
+	return humanCount(float64(d)) + " " + d.Unit()
+}
+
+// Duration returns the delta time as a duration.
+func (d DPT_13100) Duration() time.Duration {
+	return time.Duration(d) * time.Second
+}
+
+// DPT_14002 represents DPT 14.002 / Acceleration.
+type DPT_14002 float32
+
+func (d DPT_14002) Pack() []byte {
+	return packF32(float32(d))
+}
+
+func (d DPT_14002) AppendPack(dst []byte) []byte {
+	return appendF32(dst, float32(d))
+}
+
+func (d *DPT_14002) Unpack(data []byte) error {
+	return unpackF32(data, (*float32)(d))
+}
+
+func (d DPT_14002) Unit() string {
+	return "m/s²"
+}
+
+func (d DPT_14002) String() string {
+	return fmt.Sprintf("%.2f m/s²", float32(d))
+}
+
+// DPT_14003 represents DPT 14.003 / Acceleration angular.
+type DPT_14003 float32
+
+func (d DPT_14003) Pack() []byte {
+	return packF32(float32(d))
+}
+
+func (d DPT_14003) AppendPack(dst []byte) []byte {
+	return appendF32(dst, float32(d))
+}
+
+func (d *DPT_14003) Unpack(data []byte) error {
+	return unpackF32(data, (*float32)(d))
+}
+
+func (d DPT_14003) Unit() string {
+	return "rad/s²"
+}
+
+func (d DPT_14003) String() string {
+	return fmt.Sprintf("%.2f rad/s²", float32(d))
+}
+
+// DPT_14006 represents DPT 14.006 / Angle (radian).
+type DPT_14006 float32
+
+func (d DPT_14006) Pack() []byte {
+	return packF32(float32(d))
+}
+
+func (d DPT_14006) AppendPack(dst []byte) []byte {
+	return appendF32(dst, float32(d))
+}
+
+func (d *DPT_14006) Unpack(data []byte) error {
+	return unpackF32(data, (*float32)(d))
+}
+
+func (d DPT_14006) Unit() string {
+	return "rad"
+}
+
+func (d DPT_14006) String() string {
+	return fmt.Sprintf("%.2f rad", float32(d))
+}
+
+// Degrees converts the angle to degrees.
+func (d DPT_14006) Degrees() DPT_14007 {
+	return DPT_14007(float64(d) * 180 / math.Pi)
+}
+
+// DPT_14007 represents DPT 14.007 / Angle (degree).
+type DPT_14007 float32
+
+func (d DPT_14007) Pack() []byte {
+	return packF32(float32(d))
+}
+
+func (d DPT_14007) AppendPack(dst []byte) []byte {
+	return appendF32(dst, float32(d))
+}
+
+func (d *DPT_14007) Unpack(data []byte) error {
+	return unpackF32(data, (*float32)(d))
+}
+
+func (d DPT_14007) Unit() string {
+	return "°"
+}
+
+func (d DPT_14007) String() string {
+	return fmt.Sprintf("%.2f°", float32(d))
+}
+
+// Radians converts the angle to radians.
+func (d DPT_14007) Radians() DPT_14006 {
+	return DPT_14006(float64(d) * math.Pi / 180)
+}
+
+// DPT_14056 represents DPT 14.056 / Power.
+type DPT_14056 float32
+
+func (d DPT_14056) Pack() []byte {
+	return packF32(float32(d))
+}
+
+func (d DPT_14056) AppendPack(dst []byte) []byte {
+	return appendF32(dst, float32(d))
+}
+
+func (d *DPT_14056) Unpack(data []byte) error {
+	return unpackF32(data, (*float32)(d))
+}
+
+func (d DPT_14056) Unit() string {
+	return "W"
+}
+
+func (d DPT_14056) String() string {
+	return fmt.Sprintf("%.2f W", float32(d))
+}
+
+// Kilowatts returns the power in kW.
+func (d DPT_14056) Kilowatts() float32 {
+	return float32(d) / 1e3
+}
+
+// Megawatts returns the power in MW.
+func (d DPT_14056) Megawatts() float32 {
+	return float32(d) / 1e6
+}
+
+// DPT_14065 represents DPT 14.065 / Speed.
+type DPT_14065 float32
+
+func (d DPT_14065) Pack() []byte {
+	return packF32(float32(d))
+}
+
+func (d DPT_14065) AppendPack(dst []byte) []byte {
+	return appendF32(dst, float32(d))
+}
+
+func (d *DPT_14065) Unpack(data []byte) error {
+	return unpackF32(data, (*float32)(d))
+}
+
+func (d DPT_14065) Unit() string {
+	return "m/s"
+}
+
+func (d DPT_14065) String() string {
+	return fmt.Sprintf("%.2f m/s", float32(d))
+}
+
+// NewDPT_14065FromKilometersPerHour creates a DPT_14065 from a speed in km/h.
+func NewDPT_14065FromKilometersPerHour(kmh float32) DPT_14065 {
+	return DPT_14065(kmh / 3.6)
+}
+
+// KilometersPerHour returns the speed in km/h.
+func (d DPT_14065) KilometersPerHour() float32 {
+	return float32(d) * 3.6
+}
+
+// DPT_15000 represents DPT 15.000 / Access Data.
+//
+// AccessCode holds the six digit access identification code, which is BCD-encoded on the wire.
+// Codes above 999999 are clamped when packing.
+type DPT_15000 struct {
+	AccessCode    uint32
+	Error         bool
+	Permission    bool
+	ReadDirection bool
+	Encryption    bool
+	Index         uint8
+}
+
+func (d DPT_15000) Pack() []byte {
+	code := d.AccessCode
+	if code > 999999 {
+		code = 999999
+	}
+
+	buffer := []byte{0, 0, 0, 0, 0}
+
+	for i := 3; i >= 1; i-- {
+		buffer[i] = uint8(code%10) | uint8(code/10%10)<<4
+		code /= 100
+	}
+
+	for bit, flag := range []bool{d.Encryption, d.ReadDirection, d.Permission, d.Error} {
+		buffer[4] = PackBit(buffer[4], 4+bit, flag)
+	}
+	buffer[4] |= d.Index & 15
+
+	return buffer
+}
+
+func (d *DPT_15000) Unpack(data []byte) error {
+	if len(data) != 5 {
+		return ErrInvalidLength
+	}
+
+	var code uint32
+	for _, b := range data[1:4] {
+		high, low := b>>4, b&15
+		if high > 9 || low > 9 {
+			return fmt.Errorf("Access code contains invalid BCD octet %#02x", b)
+		}
+
+		code = code*100 + uint32(high)*10 + uint32(low)
+	}
+
+	*d = DPT_15000{
+		AccessCode:    code,
+		Error:         UnpackBit(data[4], 7),
+		Permission:    UnpackBit(data[4], 6),
+		ReadDirection: UnpackBit(data[4], 5),
+		Encryption:    UnpackBit(data[4], 4),
+		Index:         data[4] & 15,
+	}
+
+	return nil
+}
+
+func (d DPT_15000) Unit() string {
+	return ""
+}
+
+func (d DPT_15000) String() string {
+	return fmt.Sprintf("Code: %06d Index: %d Error: %t Permission: %t ReadDirection: %t Encryption: %t",
+		d.AccessCode, d.Index, d.Error, d.Permission, d.ReadDirection, d.Encryption)
+}
+
+// DPT_16000 represents DPT 16.000 / ASCII String.
+//
+// Strings are limited to 14 characters; longer strings are truncated when packing.
+type DPT_16000 string
+
+func (d DPT_16000) Pack() []byte {
+	return d.PackPadded(0)
+}
+
+// PackPadded packs the string like Pack, but fills the remainder of the 14 character field with
+// the given pad byte. Some displays expect space padding (0x20) instead of null padding.
+func (d DPT_16000) PackPadded(pad byte) []byte {
+	return packA14(string(d), pad)
+}
+
+// Unpack the string. Trailing null and space padding is removed.
+func (d *DPT_16000) Unpack(data []byte) error {
+	return unpackA14(data, (*string)(d))
+}
+
+func (d DPT_16000) Unit() string {
+	return ""
+}
+
+func (d DPT_16000) String() string {
+	return string(d)
+}
+
+// WouldTruncate reports whether the string is longer than the 14 characters that fit into the
+// packed value, so packing it would lose the excess characters.
+func (d DPT_16000) WouldTruncate() bool {
+	return len(d) > 14
+}
+
+// NewDPT_16000Justified creates a DPT_16000 which holds s padded with spaces to the given width,
+// e.g. to right-justify numbers on a display. The width is limited to 14 characters and longer
+// strings are cut to the width.
+func NewDPT_16000Justified(s string, width int, right bool) DPT_16000 {
+	if width > 14 {
+		width = 14
+	} else if width < 0 {
+		width = 0
+	}
+
+	if len(s) > width {
+		s = s[:width]
+	}
+
+	padding := strings.Repeat(" ", width-len(s))
+	if right {
+		return DPT_16000(padding + s)
+	}
+
+	return DPT_16000(s + padding)
+}
+
+// DPT_18001 represents DPT 18.001 / Scene Control.
+//
+// Scene holds the scene number as transmitted, from 0 to 63. Users usually count scenes from 1, so
+// 0 denotes scene 1.
+type DPT_18001 struct {
+	Learn bool
+	Scene uint8
+}
+
+func (d DPT_18001) Pack() []byte {
+	octet := d.Scene & 0x3f
+	if d.Learn {
+		octet |= 0x80
+	}
+
+	return packU8(octet)
+}
+
+func (d *DPT_18001) Unpack(data []byte) error {
+	var octet uint8
+	if err := unpackU8(data, &octet); err != nil {
+		return err
+	}
+
+	*d = DPT_18001{
+		Learn: octet&0x80 != 0,
+		Scene: octet & 0x3f,
+	}
+
+	return nil
+}
+
+func (d DPT_18001) Unit() string {
+	return ""
+}
+
+func (d DPT_18001) String() string {
+	if d.Learn {
+		return fmt.Sprintf("Learn scene %d", d.Scene+1)
+	} else {
+		return fmt.Sprintf("Activate scene %d", d.Scene+1)
+	}
 }
 
-func (d DPT_12001) Unit() string {
-	return "pulses"
+// DPT_20102 represents DPT 20.102 / HVAC Mode.
+//
+// Unpack keeps reserved codes as they are, so values that are unknown to this package survive
+// a round trip unchanged.
+type DPT_20102 uint8
+
+var hvacModeNames = map[DPT_20102]string{
+	0: "Auto",
+	1: "Comfort",
+	2: "Standby",
+	3: "Economy",
+	4: "Building Protection",
 }
 
-func (d DPT_12001) String() string {
-	return fmt.Sprintf("%d pulses", uint32(d))
+func (d DPT_20102) Pack() []byte {
+	return packU8(uint8(d))
 }
 
-// DPT_13001 represents DPT 13.001 / counter value.
-type DPT_13001 int32
+func (d DPT_20102) AppendPack(dst []byte) []byte {
+	return appendU8(dst, uint8(d))
+}
 
-func (d DPT_13001) Pack() []byte {
-	return packV32(int32(d))
+func (d *DPT_20102) Unpack(data []byte) error {
+	return unpackU8(data, (*uint8)(d))
 }
 
-func (d *DPT_13001) Unpack(data []byte) error {
-	return unpackV32(data, (*int32)(d))
+func (d DPT_20102) Unit() string {
+	return ""
 }
 
-func (d DPT_13001) Unit() string {
-	return "pulses"
+func (d DPT_20102) String() string {
+	if name, ok := hvacModeNames[d]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint8(d))
 }
 
-func (d DPT_13001) String() string {
-	return fmt.Sprintf("%d pulses", int32(d))
+// ErrUnknownHVACMode is returned when parsing a name which is not an HVAC mode.
+var ErrUnknownHVACMode = errors.New("Unknown HVAC mode name")
+
+// ParseHVACMode parses the name of an HVAC mode as returned by String (e.g. "Comfort"). The name is
+// matched case-insensitively.
+func ParseHVACMode(s string) (DPT_20102, error) {
+	s = strings.TrimSpace(s)
+
+	for mode, name := range hvacModeNames {
+		if strings.EqualFold(s, name) {
+			return mode, nil
+		}
+	}
+
+	return 0, ErrUnknownHVACMode
+}
+
+// DPT_20105 represents DPT 20.105 / HVAC Control Mode.
+//
+// Unpack keeps reserved codes as they are, so values that are unknown to this package survive
+// a round trip unchanged.
+type DPT_20105 uint8
+
+var hvacControlModeNames = map[DPT_20105]string{
+	0:  "Auto",
+	1:  "Heat",
+	2:  "Morning Warmup",
+	3:  "Cool",
+	4:  "Night Purge",
+	5:  "Precool",
+	6:  "Off",
+	7:  "Test",
+	8:  "Emergency Heat",
+	9:  "Fan Only",
+	10: "Free Cool",
+	11: "Ice",
+	12: "Maximum Heating",
+	13: "Economic Heat/Cool",
+	14: "Dehumidification",
+	15: "Calibration",
+	16: "Emergency Cool",
+	17: "Emergency Steam",
+	20: "NoDem",
+}
+
+func (d DPT_20105) Pack() []byte {
+	return packU8(uint8(d))
 }
 
-// DPT_13002 represents DPT 13.002 / flow rate.
-type DPT_13002 int32
+func (d DPT_20105) AppendPack(dst []byte) []byte {
+	return appendU8(dst, uint8(d))
+}
 
-func (d DPT_13002) Pack() []byte {
-	return packV32(int32(d))
+func (d *DPT_20105) Unpack(data []byte) error {
+	return unpackU8(data, (*uint8)(d))
 }
 
-func (d *DPT_13002) Unpack(data []byte) error {
-	return unpackV32(data, (*int32)(d))
+func (d DPT_20105) Unit() string {
+	return ""
 }
 
-func (d DPT_13002) Unit() string {
-	return "m^3/h"
+func (d DPT_20105) String() string {
+	if name, ok := hvacControlModeNames[d]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint8(d))
 }
 
-func (d DPT_13002) String() string {
-	return fmt.Sprintf("%d m^3/h", int32(d))
+// DPT_20107 represents DPT 20.107 / Changeover Mode.
+//
+// Unpack keeps reserved codes as they are, so values that are unknown to this package survive
+// a round trip unchanged.
+type DPT_20107 uint8
+
+var changeoverModeNames = map[DPT_20107]string{
+	0: "Auto",
+	1: "Cooling Only",
+	2: "Heating Only",
 }
 
-// DPT_13010 represents DPT 13.010 / active energy.
-type DPT_13010 int32
+func (d DPT_20107) Pack() []byte {
+	return packU8(uint8(d))
+}
 
-func (d DPT_13010) Pack() []byte {
-	return packV32(int32(d))
+func (d DPT_20107) AppendPack(dst []byte) []byte {
+	return appendU8(dst, uint8(d))
 }
 
-func (d *DPT_13010) Unpack(data []byte) error {
-	return unpackV32(data, (*int32)(d))
+func (d *DPT_20107) Unpack(data []byte) error {
+	return unpackU8(data, (*uint8)(d))
 }
 
-func (d DPT_13010) Unit() string {
-	return "Wh"
+func (d DPT_20107) Unit() string {
+	return ""
 }
 
-func (d DPT_13010) String() string {
-	return fmt.Sprintf("%d Wh", int32(d))
+func (d DPT_20107) String() string {
+	if name, ok := changeoverModeNames[d]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint8(d))
 }
 
-// DPT_13011 represents DPT 13.011 / apparant energy.
-type DPT_13011 int32
+// DPT_28001 represents DPT 28.001 / UTF-8 String.
+//
+// The packed string is terminated by a null character, hence its length varies. Pack only
+// includes the string up to an embedded null character and silently drops the rest, so such a
+// string does not survive a pack/unpack round trip; WouldTruncate detects this. Unpack rejects
+// data which contains anything after the terminator.
+type DPT_28001 string
 
-func (d DPT_13011) Pack() []byte {
-	return packV32(int32(d))
+func (d DPT_28001) Pack() []byte {
+	str := string(d)
+	if i := strings.IndexByte(str, 0); i >= 0 {
+		str = str[:i]
+	}
+
+	buffer := make([]byte, len(str)+2)
+	copy(buffer[1:], str)
+
+	return buffer
 }
 
-func (d *DPT_13011) Unpack(data []byte) error {
-	return unpackV32(data, (*int32)(d))
+func (d *DPT_28001) Unpack(data []byte) error {
+	length, ok := d.packedLength(data)
+	if !ok {
+		return ErrInvalidLength
+	}
+
+	if length != len(data) {
+		return errors.New("UTF-8 string contains an embedded null character")
+	}
+
+	str := data[1 : length-1]
+	if !utf8.Valid(str) {
+		return errors.New("UTF-8 string is not valid UTF-8")
+	}
+
+	*d = DPT_28001(str)
+
+	return nil
 }
 
-func (d DPT_13011) Unit() string {
-	return "VAh"
+func (d DPT_28001) packedLength(data []byte) (int, bool) {
+	if len(data) < 2 {
+		return 0, false
+	}
+
+	i := bytes.IndexByte(data[1:], 0)
+	if i < 0 {
+		return 0, false
+	}
+
+	return i + 2, true
 }
 
-func (d DPT_13011) String() string {
-	return fmt.Sprintf("%d VAh", int32(d))
+func (d DPT_28001) Unit() string {
+	return ""
 }
 
-// DPT_13012 represents DPT 13.012 / reactive energy.
-type DPT_13012 int32
+func (d DPT_28001) String() string {
+	return string(d)
+}
 
-func (d DPT_13012) Pack() []byte {
-	return packV32(int32(d))
+// WouldTruncate reports whether the string contains a null character, so packing it would lose
+// the characters from there on.
+func (d DPT_28001) WouldTruncate() bool {
+	return strings.IndexByte(string(d), 0) >= 0
 }
 
-func (d *DPT_13012) Unpack(data []byte) error {
-	return unpackV32(data, (*int32)(d))
+// DPT_206100 represents DPT 206.100 / HVAC Mode Next.
+//
+// Delay is the time in minutes until Mode takes effect.
+type DPT_206100 struct {
+	Delay uint16
+	Mode  DPT_20102
 }
 
-func (d DPT_13012) Unit() string {
-	return "VARh"
+func (d DPT_206100) Pack() []byte {
+	return []byte{0, uint8(d.Delay >> 8), uint8(d.Delay), uint8(d.Mode)}
 }
 
-func (d DPT_13012) String() string {
-	return fmt.Sprintf("%d VARh", int32(d))
+func (d *DPT_206100) Unpack(data []byte) error {
+	if len(data) != 4 {
+		return ErrInvalidLength
+	}
+
+	*d = DPT_206100{
+		Delay: uint16(data[1])<<8 | uint16(data[2]),
+		Mode:  DPT_20102(data[3]),
+	}
+
+	return nil
 }
 
-// DPT_13013 represents DPT 13.010 / active energy (kWh).
-type DPT_13013 int32
+func (d DPT_206100) Unit() string {
+	return ""
+}
 
-func (d DPT_13013) Pack() []byte {
-	return packV32(int32(d))
+func (d DPT_206100) String() string {
+	return fmt.Sprintf("%s in %d min", d.Mode, d.Delay)
 }
 
-func (d *DPT_13013) Unpack(data []byte) error {
-	return unpackV32(data, (*int32)(d))
+// Duration returns the delay as a duration.
+func (d DPT_206100) Duration() time.Duration {
+	return time.Duration(d.Delay) * time.Minute
 }
 
-func (d DPT_13013) Unit() string {
-	return "kWh"
+// DPT_232600 represents DPT 232.600 / Colour RGB.
+type DPT_232600 struct {
+	Red   uint8
+	Green uint8
+	Blue  uint8
 }
 
-func (d DPT_13013) String() string {
-	return fmt.Sprintf("%d kWh", int32(d))
+func (d DPT_232600) Pack() []byte {
+	return []byte{0, d.Red, d.Green, d.Blue}
 }
 
-// DPT_13014 represents DPT 13.014 / apparant energy (kVAh).
-type DPT_13014 int32
+func (d *DPT_232600) Unpack(data []byte) error {
+	if len(data) != 4 {
+		return ErrInvalidLength
+	}
 
-func (d DPT_13014) Pack() []byte {
-	return packV32(int32(d))
+	*d = DPT_232600{
+		Red:   data[1],
+		Green: data[2],
+		Blue:  data[3],
+	}
+
+	return nil
 }
 
-func (d *DPT_13014) Unpack(data []byte) error {
-	return unpackV32(data, (*int32)(d))
+func (d DPT_232600) Unit() string {
+	return ""
 }
 
-func (d DPT_13014) Unit() string {
-	return "kVAh"
+func (d DPT_232600) String() string {
+	return fmt.Sprintf("R: %d G: %d B: %d", d.Red, d.Green, d.Blue)
 }
 
-func (d DPT_13014) String() string {
-	return fmt.Sprintf("%d kVAh", int32(d))
+// ErrInvalidHexColor is returned when a hex colour string is not of the form "#RRGGBB".
+var ErrInvalidHexColor = errors.New("Invalid hex colour string")
+
+// NewDPT_232600FromHex parses a hex colour string as used by web UIs. Both "#RRGGBB" and "RRGGBB"
+// are accepted, in upper or lower case.
+func NewDPT_232600FromHex(s string) (DPT_232600, error) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return DPT_232600{}, ErrInvalidHexColor
+	}
+
+	rgb, err := hex.DecodeString(s)
+	if err != nil {
+		return DPT_232600{}, ErrInvalidHexColor
+	}
+
+	return DPT_232600{Red: rgb[0], Green: rgb[1], Blue: rgb[2]}, nil
 }
 
-// DPT_13015 represents DPT 13.015 / reactive energy (kVARh).
-type DPT_13015 int32
+// HexString formats the colour as "#RRGGBB".
+func (d DPT_232600) HexString() string {
+	return fmt.Sprintf("#%02X%02X%02X", d.Red, d.Green, d.Blue)
+}
 
-func (d DPT_13015) Pack() []byte {
-	return packV32(int32(d))
+// HSV converts the colour to its HSV representation.
+func (d DPT_232600) HSV() DPT_232601 {
+	r := float64(d.Red) / 255
+	g := float64(d.Green) / 255
+	b := float64(d.Blue) / 255
+
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	delta := max - min
+
+	var hue float64
+	if delta > 0 {
+		switch max {
+		case r:
+			hue = 60 * math.Mod((g-b)/delta, 6)
+		case g:
+			hue = 60 * ((b-r)/delta + 2)
+		default:
+			hue = 60 * ((r-g)/delta + 4)
+		}
+	}
+
+	if hue < 0 {
+		hue += 360
+	}
+
+	var saturation float64
+	if max > 0 {
+		saturation = delta / max
+	}
+
+	return DPT_232601{
+		Hue:        float32(hue),
+		Saturation: float32(saturation * 100),
+		Value:      float32(max * 100),
+	}
 }
 
-func (d *DPT_13015) Unpack(data []byte) error {
-	return unpackV32(data, (*int32)(d))
+// DPT_232601 represents DPT 232.601 / Colour HSV.
+type DPT_232601 struct {
+	Hue        float32
+	Saturation float32
+	Value      float32
 }
 
-func (d DPT_13015) Unit() string {
-	return "kVARh"
+func (d DPT_232601) Pack() []byte {
+	return []byte{
+		0,
+		packAngleOctet(float64(d.Hue)),
+		packScaledOctet(d.Saturation, 100),
+		packScaledOctet(d.Value, 100),
+	}
 }
 
-func (d DPT_13015) String() string {
-	return fmt.Sprintf("%d kVARh", int32(d))
+func (d *DPT_232601) Unpack(data []byte) error {
+	if len(data) != 4 {
+		return ErrInvalidLength
+	}
+
+	*d = DPT_232601{
+		Hue:        unpackScaledOctet(data[1], 360),
+		Saturation: unpackScaledOctet(data[2], 100),
+		Value:      unpackScaledOctet(data[3], 100),
+	}
+
+	return nil
+}
+
+func (d DPT_232601) Unit() string {
+	return ""
+}
+
+func (d DPT_232601) String() string {
+	return fmt.Sprintf("H: %.2f° S: %.2f%% V: %.2f%%", d.Hue, d.Saturation, d.Value)
+}
+
+// RGB converts the colour to its RGB representation.
+func (d DPT_232601) RGB() DPT_232600 {
+	hue := math.Mod(float64(d.Hue), 360)
+	if hue < 0 {
+		hue += 360
+	}
+
+	value := math.Max(0, math.Min(1, float64(d.Value)/100))
+	saturation := math.Max(0, math.Min(1, float64(d.Saturation)/100))
+
+	c := value * saturation
+	x := c * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	m := value - c
+
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g, b = c, x, 0
+	case hue < 120:
+		r, g, b = x, c, 0
+	case hue < 180:
+		r, g, b = 0, c, x
+	case hue < 240:
+		r, g, b = 0, x, c
+	case hue < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return DPT_232600{
+		Red:   uint8(math.Floor((r+m)*255 + 0.5)),
+		Green: uint8(math.Floor((g+m)*255 + 0.5)),
+		Blue:  uint8(math.Floor((b+m)*255 + 0.5)),
+	}
+}
+
+// DPT_242600 represents DPT 242.600 / Colour xyY.
+type DPT_242600 struct {
+	X               float32
+	Y               float32
+	Brightness      float32
+	ColorValid      bool
+	BrightnessValid bool
+}
+
+func (d DPT_242600) Pack() []byte {
+	x := uint16(math.Floor(math.Max(0, math.Min(1, float64(d.X)))*65535 + 0.5))
+	y := uint16(math.Floor(math.Max(0, math.Min(1, float64(d.Y)))*65535 + 0.5))
+
+	var valid uint8
+	if d.ColorValid {
+		valid |= 1 << 1
+	}
+	if d.BrightnessValid {
+		valid |= 1
+	}
+
+	return []byte{
+		0,
+		uint8(x >> 8), uint8(x),
+		uint8(y >> 8), uint8(y),
+		packScaledOctet(d.Brightness, 100),
+		valid,
+	}
+}
+
+func (d *DPT_242600) Unpack(data []byte) error {
+	if len(data) != 7 {
+		return ErrInvalidLength
+	}
+
+	*d = DPT_242600{
+		X:               float32(uint16(data[1])<<8|uint16(data[2])) / 65535,
+		Y:               float32(uint16(data[3])<<8|uint16(data[4])) / 65535,
+		Brightness:      unpackScaledOctet(data[5], 100),
+		ColorValid:      data[6]&(1<<1) != 0,
+		BrightnessValid: data[6]&1 != 0,
+	}
+
+	return nil
+}
+
+func (d DPT_242600) Unit() string {
+	return ""
+}
+
+func (d DPT_242600) String() string {
+	return fmt.Sprintf("x: %.4f y: %.4f Y: %.2f%%", d.X, d.Y, d.Brightness)
+}
+
+// Valid determines whether the value describes a valid chromaticity, i.e. x and y are not
+// negative and x + y does not exceed 1, and whether the brightness is within [0, 100].
+func (d DPT_242600) Valid() bool {
+	return d.X >= 0 && d.Y >= 0 && d.X+d.Y <= 1 && d.Brightness >= 0 && d.Brightness <= 100
+}
+
+// DPT_250600 represents DPT 250.600 / Brightness Colour Temperature Control.
+type DPT_250600 struct {
+	ColorTempIncrease  bool
+	ColorTempStep      uint8
+	ColorTempValid     bool
+	BrightnessIncrease bool
+	BrightnessStep     uint8
+	BrightnessValid    bool
+}
+
+func (d DPT_250600) Pack() []byte {
+	var valid uint8
+	if d.ColorTempValid {
+		valid |= 1 << 1
+	}
+	if d.BrightnessValid {
+		valid |= 1
+	}
+
+	return []byte{
+		0,
+		packB1U3Octet(d.ColorTempIncrease, d.ColorTempStep),
+		packB1U3Octet(d.BrightnessIncrease, d.BrightnessStep),
+		valid,
+	}
+}
+
+func (d *DPT_250600) Unpack(data []byte) error {
+	if len(data) != 4 {
+		return ErrInvalidLength
+	}
+
+	var value DPT_250600
+	unpackB1U3Octet(data[1], &value.ColorTempIncrease, &value.ColorTempStep)
+	unpackB1U3Octet(data[2], &value.BrightnessIncrease, &value.BrightnessStep)
+	value.ColorTempValid = data[3]&(1<<1) != 0
+	value.BrightnessValid = data[3]&1 != 0
+
+	*d = value
+
+	return nil
+}
+
+func (d DPT_250600) Unit() string {
+	return ""
+}
+
+func (d DPT_250600) String() string {
+	return fmt.Sprintf("Colour temperature: %s, Brightness: %s",
+		stepControlString(d.ColorTempIncrease, d.ColorTempStep, d.ColorTempValid),
+		stepControlString(d.BrightnessIncrease, d.BrightnessStep, d.BrightnessValid))
+}
+
+func stepControlString(increase bool, step uint8, valid bool) string {
+	if !valid {
+		return "invalid"
+	} else if increase {
+		return fmt.Sprintf("Increase by %d", step)
+	} else {
+		return fmt.Sprintf("Decrease by %d", step)
+	}
+}
+
+// Counter24 represents a 3-octet unsigned counter as exposed by some gateways. The KNX
+// specification does not define a datapoint type for it, hence it is not part of the registry.
+// Values above 16777215 are clamped when packing.
+type Counter24 uint32
+
+func (d Counter24) Pack() []byte {
+	return packU24(uint32(d))
+}
+
+func (d *Counter24) Unpack(data []byte) error {
+	return unpackU24(data, (*uint32)(d))
+}
+
+func (d Counter24) Unit() string {
+	return "pulses"
+}
+
+func (d Counter24) String() string {
+	return fmt.Sprintf("%d pulses", uint32(d))
+}
+
+// StatusByte combines several 1-bit values into a single status octet, e.g. the status object of
+// an actuator. Bit 0 is the least significant bit.
+type StatusByte uint8
+
+// Set sets or clears the bit at the given index. Indices outside of [0, 7] are ignored.
+func (d *StatusByte) Set(bit int, v bool) {
+	*d = StatusByte(PackBit(byte(*d), bit, v))
+}
+
+// Bit reads the bit at the given index. Indices outside of [0, 7] yield false.
+func (d StatusByte) Bit(bit int) bool {
+	return UnpackBit(byte(d), bit)
+}
+
+func (d StatusByte) Pack() []byte {
+	return packU8(uint8(d))
+}
+
+func (d *StatusByte) Unpack(data []byte) error {
+	return unpackU8(data, (*uint8)(d))
+}
+
+func (d StatusByte) String() string {
+	return fmt.Sprintf("%08b", uint8(d))
+}
+
+// DPT_Raw holds application data of an unknown datapoint type as it is.
+type DPT_Raw []byte
+
+func (d DPT_Raw) Pack() []byte {
+	buffer := make([]byte, len(d))
+	copy(buffer, d)
+	return buffer
+}
+
+func (d *DPT_Raw) Unpack(data []byte) error {
+	*d = make(DPT_Raw, len(data))
+	copy(*d, data)
+	return nil
+}
+
+func (d DPT_Raw) Unit() string {
+	return ""
+}
+
+// String returns the length of the data followed by its octets, e.g. "[2] 0x0C 0x33".
+func (d DPT_Raw) String() string {
+	if len(d) == 0 {
+		return "[0]"
+	}
+
+	return fmt.Sprintf("[%d] %s", len(d), hexOctets(d))
 }
@@ -4,15 +4,19 @@
 package dpt
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
 	"math"
 	"math/rand"
+	"sort"
+	"strings"
+	"time"
 )
 
 // Define epsilon constant for floating point checks
-const epsilon = 1E-3
+const epsilon = 1e-3
 
 func abs(x float32) float32 {
 	if x < 0.0 {
@@ -154,6 +158,155 @@ func TestDPT_1010(t *testing.T) {
 	}
 }
 
+// Test DPT 1.015 (Reset) with values within range
+func TestDPT_1015(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_1015
+
+	for _, value := range []bool{true, false} {
+		src = DPT_1015(value)
+		if bool(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if bool(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	if s := DPT_1015(true).String(); s != "Reset" {
+		t.Errorf("Wrong label \"%s\" for true, expected \"Reset\".", s)
+	}
+	if s := DPT_1015(false).String(); s != "No action" {
+		t.Errorf("Wrong label \"%s\" for false, expected \"No action\".", s)
+	}
+}
+
+// Test DPT 1.017 (Trigger) with values within range
+func TestDPT_1017(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_1017
+
+	for _, value := range []bool{true, false} {
+		src = DPT_1017(value)
+		if bool(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if bool(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	if s := DPT_1017(true).String(); s != "Trigger" {
+		t.Errorf("Wrong label \"%s\" for true, expected \"Trigger\".", s)
+	}
+	if s := DPT_1017(false).String(); s != "—" {
+		t.Errorf("Wrong label \"%s\" for false, expected \"—\".", s)
+	}
+}
+
+// Test the named values of DPT 1.xxx
+func TestDPT_1xxxConstants(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    bool
+		expected bool
+	}{
+		{"DPT_1001_On", bool(DPT_1001_On), true},
+		{"DPT_1001_Off", bool(DPT_1001_Off), false},
+		{"DPT_1002_True", bool(DPT_1002_True), true},
+		{"DPT_1002_False", bool(DPT_1002_False), false},
+		{"DPT_1003_Enable", bool(DPT_1003_Enable), true},
+		{"DPT_1003_Disable", bool(DPT_1003_Disable), false},
+		{"DPT_1008_Up", bool(DPT_1008_Up), true},
+		{"DPT_1008_Down", bool(DPT_1008_Down), false},
+		{"DPT_1009_Close", bool(DPT_1009_Close), true},
+		{"DPT_1009_Open", bool(DPT_1009_Open), false},
+		{"DPT_1010_Start", bool(DPT_1010_Start), true},
+		{"DPT_1010_Stop", bool(DPT_1010_Stop), false},
+		{"DPT_1015_Reset", bool(DPT_1015_Reset), true},
+		{"DPT_1015_NoAction", bool(DPT_1015_NoAction), false},
+		{"DPT_1017_Trigger", bool(DPT_1017_Trigger), true},
+	}
+
+	for _, c := range cases {
+		if c.value != c.expected {
+			t.Errorf("%s is %v, expected %v.", c.name, c.value, c.expected)
+		}
+	}
+
+	if DPT_1001_On.String() != "On" || DPT_1009_Close.String() != "Close" || DPT_1008_Up.String() != "Up" {
+		t.Errorf("Named values do not match their labels.")
+	}
+}
+
+// Test inversion of DPT 1.xxx values
+func TestDPT_1xxxNot(t *testing.T) {
+	if v := DPT_1009(true).Not(); v != DPT_1009(false) {
+		t.Errorf("Close inverts to \"%v\", expected Open.", v)
+	}
+
+	cases := []struct {
+		value    interface{}
+		expected interface{}
+	}{
+		{DPT_1001_On.Not(), DPT_1001_Off},
+		{DPT_1002_False.Not(), DPT_1002_True},
+		{DPT_1003_Enable.Not(), DPT_1003_Disable},
+		{DPT_1008_Up.Not(), DPT_1008_Down},
+		{DPT_1009_Open.Not(), DPT_1009_Close},
+		{DPT_1010_Start.Not(), DPT_1010_Stop},
+		{DPT_1015_NoAction.Not(), DPT_1015_Reset},
+		{DPT_1017_Trigger.Not(), DPT_1017(false)},
+	}
+
+	for _, c := range cases {
+		if c.value != c.expected {
+			t.Errorf("Inverted value is \"%v\" (%T), expected \"%v\" (%T).", c.value, c.value, c.expected, c.expected)
+		}
+	}
+}
+
+// Test conversion of DPT 1.xxx values from and to integers
+func TestDPT_1xxxInt(t *testing.T) {
+	cases := []struct {
+		value    int
+		expected int
+	}{
+		{DPT_1001_On.Int(), 1},
+		{DPT_1001_Off.Int(), 0},
+		{DPT_1002_True.Int(), 1},
+		{DPT_1003_Disable.Int(), 0},
+		{DPT_1008_Up.Int(), 1},
+		{DPT_1009_Open.Int(), 0},
+		{DPT_1010_Start.Int(), 1},
+		{DPT_1015_NoAction.Int(), 0},
+		{DPT_1017_Trigger.Int(), 1},
+	}
+
+	for _, c := range cases {
+		if c.value != c.expected {
+			t.Errorf("Wrong integer %d, expected %d.", c.value, c.expected)
+		}
+	}
+
+	if NewDPT_1001FromInt(1) != DPT_1001_On || NewDPT_1001FromInt(0) != DPT_1001_Off {
+		t.Errorf("Wrong DPT_1001 for 1 or 0.")
+	}
+	if NewDPT_1009FromInt(-1) != DPT_1009_Close {
+		t.Errorf("Wrong DPT_1009 for -1.")
+	}
+
+	for _, i := range []int{0, 1} {
+		if v := NewDPT_1008FromInt(i).Int(); v != i {
+			t.Errorf("Wrong integer %d after conversion! Original integer was %d.", v, i)
+		}
+	}
+}
+
 // Test DPT 3.007 (Increase/Decrease by value) with values within range
 func TestDPT_3007(t *testing.T) {
 	var buf []byte
@@ -184,6 +337,73 @@ func TestDPT_3007(t *testing.T) {
 	}
 }
 
+// Test conversion of DPT 3.007 from and to the raw nibble
+func TestDPT_3007Nibble(t *testing.T) {
+	d := NewDPT_3007FromNibble(0x0B)
+	if !d.Increase || d.Value != 3 {
+		t.Errorf("Wrong value \"%+v\" for nibble 0x0B! Expected increase by 3.", d)
+	}
+
+	d = NewDPT_3007FromNibble(0x05)
+	if d.Increase || d.Value != 5 {
+		t.Errorf("Wrong value \"%+v\" for nibble 0x05! Expected decrease by 5.", d)
+	}
+
+	for n := uint8(0); n < 16; n++ {
+		d = NewDPT_3007FromNibble(n)
+		if d.Nibble() != n {
+			t.Errorf("Wrong nibble \"%#x\" after conversion! Original nibble was \"%#x\".", d.Nibble(), n)
+		}
+		if !bytes.Equal(d.Pack(), []byte{n}) {
+			t.Errorf("Nibble \"%#x\" does not match packed value %v.", n, d.Pack())
+		}
+	}
+}
+
+// Test DPT 3.008 (Up/Down blinds) with values within range
+func TestDPT_3008(t *testing.T) {
+	var src, dst DPT_3008
+
+	for _, c := range []bool{true, false} {
+		for _, v := range genUint8Slice(0, 7, 1) {
+			src = DPT_3008{Down: c, Value: v}
+			dst.Unpack(src.Pack())
+			if dst != src {
+				t.Errorf("Wrong value \"%+v\" after pack/unpack! Original value was \"%+v\".", dst, src)
+			}
+		}
+	}
+
+	if s := (DPT_3008{Down: true, Value: 0}).String(); s != "Stop" {
+		t.Errorf("Wrong label \"%s\" for step code 0, expected \"Stop\".", s)
+	}
+}
+
+// Test mapping of DPT 1.008 directions to DPT 3.008 steps
+func TestBlindsMove(t *testing.T) {
+	cases := []struct {
+		dir      DPT_1008
+		step     uint8
+		expected []byte
+	}{
+		{DPT_1008_Up, 1, []byte{0x01}},
+		{DPT_1008_Down, 1, []byte{0x09}},
+		{DPT_1008_Down, 7, []byte{0x0f}},
+		{DPT_1008_Up, 0, []byte{0x00}},
+		{DPT_1008_Down, 0, []byte{0x08}},
+	}
+
+	for _, c := range cases {
+		move := BlindsMove(c.dir, c.step)
+		if move.Down == bool(c.dir) || move.Value != c.step {
+			t.Errorf("BlindsMove(%v, %d) yields \"%v\".", c.dir, c.step, move)
+		}
+		if buf := move.Pack(); !bytes.Equal(buf, c.expected) {
+			t.Errorf("BlindsMove(%v, %d) packs to %v, expected %v.", c.dir, c.step, buf, c.expected)
+		}
+	}
+}
+
 // Test DPT 5.001 (Scaling) with values within range
 func TestDPT_5001(t *testing.T) {
 	var buf []byte
@@ -211,6 +431,174 @@ func TestDPT_5001(t *testing.T) {
 	}
 }
 
+// Test DPT 5.001 (Scaling) conversion from and to fractions
+func TestDPT_5001Fraction(t *testing.T) {
+	if d := NewDPT_5001FromFraction(0.5); abs(float32(d)-50) > epsilon {
+		t.Errorf("Fraction 0.5 yields \"%s\", expected 50%%.", d)
+	}
+
+	if f := DPT_5001(50).Fraction(); abs(f-0.5) > epsilon {
+		t.Errorf("50%% yields fraction %f, expected 0.5.", f)
+	}
+
+	for _, f := range []float32{0, 0.25, 1} {
+		if r := NewDPT_5001FromFraction(f).Fraction(); abs(r-f) > epsilon {
+			t.Errorf("Fraction %f yields %f after conversion.", f, r)
+		}
+	}
+}
+
+// Test gamma correction of DPT 5.001 (Scaling)
+func TestDPT_5001Gamma(t *testing.T) {
+	if v := DPT_5001(50).Gamma(2.2); abs(float32(v)-21.764) > epsilon {
+		t.Errorf("50%% with gamma 2.2 yields \"%s\", expected 21.76%%.", v)
+	}
+	if v := DPT_5001(21.764).Gamma(1 / 2.2); abs(float32(v)-50) > epsilon {
+		t.Errorf("21.76%% with gamma 1/2.2 yields \"%s\", expected 50%%.", v)
+	}
+
+	for _, c := range []struct{ value, expected DPT_5001 }{{0, 0}, {100, 100}, {-10, 0}, {120, 100}} {
+		if v := c.value.Gamma(2.2); v != c.expected {
+			t.Errorf("%v with gamma 2.2 yields \"%s\", expected \"%s\".", float32(c.value), v, c.expected)
+		}
+	}
+
+	if v := DPT_5001(37).Gamma(1); abs(float32(v)-37) > epsilon {
+		t.Errorf("37%% with gamma 1 yields \"%s\".", v)
+	}
+}
+
+// Test logarithmic level mapping of DPT 5.001 (Scaling)
+func TestDPT_5001Decibels(t *testing.T) {
+	cases := []struct {
+		value    DPT_5001
+		expected float32
+	}{
+		{0, -60},
+		{50, -15.578},
+		{100, 0},
+		{-10, -60},
+		{110, 0},
+	}
+
+	for _, c := range cases {
+		if db := c.value.Decibels(-60, 0); abs(db-c.expected) > epsilon {
+			t.Errorf("%v%% yields %v dB, expected %v dB.", float32(c.value), db, c.expected)
+		}
+	}
+
+	if db := DPT_5001(25).Decibels(-60, 0); db <= DPT_5001(25).Fraction()*60-60 {
+		t.Errorf("25%% yields %v dB, which is not above the linear mapping.", db)
+	}
+}
+
+// Test blinds positions with both conventions
+func TestPosition(t *testing.T) {
+	cases := []struct {
+		value  DPT_5001
+		invert bool
+		octet  uint8
+	}{
+		{0, false, 0}, {100, false, 255},
+		{0, true, 255}, {100, true, 0},
+	}
+
+	for _, c := range cases {
+		if buf := (Position{Value: c.value, Invert: c.invert}).Pack(); buf[1] != c.octet {
+			t.Errorf("%v (inverted: %v) packs to %d, expected %d.", c.value, c.invert, buf[1], c.octet)
+		}
+
+		dst := Position{Invert: c.invert}
+		dst.Unpack([]byte{0, c.octet})
+		if abs(float32(dst.Value-c.value)) > epsilon {
+			t.Errorf("%d (inverted: %v) unpacks to \"%s\", expected \"%s\".", c.octet, c.invert, dst, c.value)
+		}
+	}
+
+	for _, invert := range []bool{false, true} {
+		dst := Position{Invert: invert}
+		dst.Unpack(Position{Value: 25, Invert: invert}.Pack())
+		if abs(float32(dst.Value)-25) > float32(100)/255+epsilon {
+			t.Errorf("25%% (inverted: %v) yields \"%s\" after pack/unpack.", invert, dst)
+		}
+	}
+
+	// The inversion only applies to the position, not to plain DPT_5001 values.
+	if buf := DPT_5001(0).Pack(); buf[1] != 0 {
+		t.Errorf("0%% packs to %d, expected 0.", buf[1])
+	}
+}
+
+// Test DPT 5.001 (Scaling) with automatic operation sentinel
+func TestDPT_5001Auto(t *testing.T) {
+	var plain DPT_5001
+	plain.Unpack([]byte{0, 255})
+	if abs(float32(plain)-100) > epsilon {
+		t.Errorf("255 unpacks to \"%s\" without sentinel, expected 100%%.", plain)
+	}
+
+	var dst DPT_5001Auto
+	dst.Unpack([]byte{0, 255})
+	if !dst.Auto || dst.String() != "Auto" {
+		t.Errorf("255 unpacks to \"%+v\" with sentinel, expected auto.", dst)
+	}
+
+	if buf := (DPT_5001Auto{Auto: true}).Pack(); !bytes.Equal(buf, []byte{0, 255}) {
+		t.Errorf("Auto packs to %v, expected [0 255].", buf)
+	}
+	if buf := (DPT_5001Auto{Value: 100}).Pack(); !bytes.Equal(buf, []byte{0, 254}) {
+		t.Errorf("100%% packs to %v with sentinel, expected [0 254].", buf)
+	}
+
+	for _, value := range []DPT_5001{0, 25, 50, 99} {
+		src := DPT_5001Auto{Value: value}
+		dst.Unpack(src.Pack())
+		if dst.Auto || abs(float32(dst.Value-value)) > float32(100)/255+epsilon {
+			t.Errorf("Wrong value \"%+v\" after pack/unpack! Original value was \"%+v\".", dst, src)
+		}
+	}
+
+	if err := dst.Unpack([]byte{0}); err != ErrInvalidLength {
+		t.Errorf("Unpacking short data yields %v instead of ErrInvalidLength.", err)
+	}
+}
+
+// Test mapping of tilt angles onto DPT 5.001 (Scaling)
+func TestTiltValue(t *testing.T) {
+	tilt := TiltValue{Angle: 45, Min: 0, Max: 90}
+	if p := tilt.Percent(); abs(float32(p)-50) > epsilon {
+		t.Errorf("45° yields \"%s\", expected 50%%.", p)
+	}
+
+	cases := []struct {
+		angle    float32
+		invert   bool
+		expected DPT_5001
+	}{
+		{0, false, 0}, {90, false, 100}, {-10, false, 0}, {120, false, 100},
+		{0, true, 100}, {90, true, 0}, {22.5, true, 75},
+	}
+	for _, c := range cases {
+		tilt := TiltValue{Angle: c.angle, Min: 0, Max: 90, Invert: c.invert}
+		if p := tilt.Percent(); abs(float32(p-c.expected)) > epsilon {
+			t.Errorf("%v° (inverted: %v) yields \"%s\", expected \"%s\".", c.angle, c.invert, p, c.expected)
+		}
+	}
+
+	for _, invert := range []bool{false, true} {
+		src := TiltValue{Angle: 30, Min: -90, Max: 90, Invert: invert}
+		dst := TiltValue{Min: -90, Max: 90, Invert: invert}
+		dst.Unpack(src.Pack())
+		if abs(dst.Angle-30) > float32(180)/255+epsilon {
+			t.Errorf("Wrong angle \"%v\" after pack/unpack! Original angle was 30°.", dst.Angle)
+		}
+	}
+
+	if buf := (TiltValue{Angle: 90, Min: 0, Max: 90}).Pack(); buf[1] != 255 {
+		t.Errorf("90° packs to %d, expected 255.", buf[1])
+	}
+}
+
 // Test DPT 5.003 (Angle) with values within range
 func TestDPT_5003(t *testing.T) {
 	var buf []byte
@@ -238,52 +626,258 @@ func TestDPT_5003(t *testing.T) {
 	}
 }
 
-// Test DPT 9.001 (Temperature) with values within range
-func TestDPT_9001(t *testing.T) {
+// Test DPT 5.001 (Scaling) and 5.003 (Angle) against known encodings
+func TestDPT_500xGolden(t *testing.T) {
+	scaling := []struct {
+		value DPT_5001
+		octet uint8
+	}{
+		{-10, 0}, {0, 0}, {10, 25}, {20, 51}, {25, 63}, {50, 127}, {75, 191}, {99.9, 254}, {100, 255},
+		{150, 255},
+	}
+	for _, c := range scaling {
+		if buf := c.value.Pack(); len(buf) != 2 || buf[0] != 0 || buf[1] != c.octet {
+			t.Errorf("\"%s\" packs to %v, expected [0 %d].", c.value, buf, c.octet)
+		}
+	}
+
+	angles := []struct {
+		value DPT_5003
+		octet uint8
+	}{
+		{0, 0}, {10, 7}, {10.588235, 7}, {90, 64}, {180, 128}, {270, 191}, {359, 254}, {360, 0}, {-90, 191},
+	}
+	for _, c := range angles {
+		if buf := c.value.Pack(); len(buf) != 2 || buf[0] != 0 || buf[1] != c.octet {
+			t.Errorf("\"%s\" packs to %v, expected [0 %d].", c.value, buf, c.octet)
+		}
+	}
+
+	// Every angle octet survives an unpack/pack round trip unchanged, except for 360° which wraps
+	// around to 0°.
+	for octet := 0; octet <= 255; octet++ {
+		var angle DPT_5003
+		angle.Unpack([]byte{0, uint8(octet)})
+		if buf := angle.Pack(); int(buf[1]) != octet%255 {
+			t.Errorf("Octet %d unpacks to \"%s\", which packs to %d.", octet, angle, buf[1])
+		}
+	}
+}
+
+// Test compass labels of DPT 5.003 (Angle)
+func TestDPT_5003Compass(t *testing.T) {
+	cases := []struct {
+		angle    DPT_5003
+		expected string
+	}{
+		{0, "N"}, {11, "N"}, {12, "NNE"}, {45, "NE"}, {90, "E"}, {180, "S"},
+		{202.5, "SSW"}, {270, "W"}, {337.5, "NNW"}, {350, "N"}, {360, "N"}, {-90, "W"},
+	}
+
+	for _, c := range cases {
+		if label := c.angle.Compass(); label != c.expected {
+			t.Errorf("Wrong compass label \"%s\" for %v, expected \"%s\".", label, c.angle, c.expected)
+		}
+	}
+
+	// Labels also work for values received from the bus.
+	var dst DPT_5003
+	dst.Unpack(DPT_5003(90).Pack())
+	if label := dst.Compass(); label != "E" {
+		t.Errorf("Wrong compass label \"%s\" for %v after pack/unpack, expected \"E\".", label, dst)
+	}
+}
+
+// Test interpolation of DPT 5.003 (Angle) along the shorter arc
+func TestDPT_5003Lerp(t *testing.T) {
+	cases := []struct {
+		from, to DPT_5003
+		t        float32
+		expected float32
+	}{
+		{350, 10, 0.5, 0},
+		{350, 10, 0.25, 355},
+		{350, 10, 0.75, 5},
+		{10, 350, 0.5, 0},
+		{10, 350, 0.75, 355},
+		{270, 45, 0.5, 337.5},
+		{0, 90, 0.5, 45},
+		{90, 0, 0.5, 45},
+		{0, 180, 0.5, 90},
+		{180, 0, 0.5, 270},
+		{350, 10, 0, 350},
+		{350, 10, 1, 10},
+		{-10, 370, 0.5, 0},
+	}
+
+	for _, c := range cases {
+		if angle := Lerp(c.from, c.to, c.t); abs(float32(angle)-c.expected) > epsilon {
+			t.Errorf("Lerp(%v, %v, %v) = %v, expected %v.", c.from, c.to, c.t, angle, c.expected)
+		}
+	}
+}
+
+// Test DPT 5.004 (Percent_U8) over the whole range
+func TestDPT_5004(t *testing.T) {
 	var buf []byte
-	var src, dst DPT_9001
+	var dst DPT_5004
 
-	for i := 1; i <= 10; i++ {
-		value := rand.Float32()
+	if buf = DPT_5004(200).Pack(); buf[1] != 200 {
+		t.Errorf("200%% packs to %d, expected 200.", buf[1])
+	}
 
-		// Scale the random number to the given range
-		value *= 670760 - -273
-		value += -273
+	for value := 0; value <= 255; value++ {
+		buf = DPT_5004(value).Pack()
+		if int(buf[1]) != value {
+			t.Errorf("%d%% packs to %d.", value, buf[1])
+		}
+		dst.Unpack(buf)
+		if int(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+}
 
-		// Calculate the quantization error we expect
-		Q := get_float_quantization_error(value, 0.01, 2047)
+// Test DPT 5.003 (Angle) normalization of values outside of [0, 360)
+func TestDPT_5003Normalization(t *testing.T) {
+	var dst DPT_5003
 
-		// Pack and unpack to test value
-		src = DPT_9001(value)
-		if abs(float32(src)-value) > epsilon {
-			t.Errorf("Assignment of value \"%v\" failed for source of type DPT_9001! Has value \"%s\".", value, src)
+	// Calculate the quantization error we expect
+	const Q = float32(360) / 255
+
+	cases := []struct {
+		value, expected float32
+	}{
+		{370, 10},
+		{-30, 330},
+		{720, 0},
+		{-360, 0},
+		{359, 359},
+	}
+
+	for _, c := range cases {
+		dst.Unpack(DPT_5003(c.value).Pack())
+		if abs(float32(dst)-c.expected) > (Q + epsilon) {
+			t.Errorf("Angle \"%v\" yields \"%s\" after pack/unpack, expected \"%v\".", c.value, dst, c.expected)
+		}
+	}
+}
+
+// Test DPT 7.002 - 7.004 (Time period) with values within range
+func TestDPT_700xTimePeriod(t *testing.T) {
+	for _, value := range []uint16{0, 1, 500, math.MaxUint16, uint16(rand.Uint32())} {
+		var ms DPT_7002
+		ms.Unpack(DPT_7002(value).Pack())
+		if uint16(ms) != value || ms.Duration() != time.Duration(value)*time.Millisecond {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", ms, value)
+		}
+
+		var ms10 DPT_7003
+		ms10.Unpack(DPT_7003(value).Pack())
+		if uint16(ms10) != value || ms10.Duration() != time.Duration(value)*10*time.Millisecond {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", ms10, value)
+		}
+
+		var ms100 DPT_7004
+		ms100.Unpack(DPT_7004(value).Pack())
+		if uint16(ms100) != value || ms100.Duration() != time.Duration(value)*100*time.Millisecond {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", ms100, value)
+		}
+	}
+
+	if d := DPT_7003(500).Duration(); d != 5*time.Second {
+		t.Errorf("500 units of 10 ms yield %v, expected 5s.", d)
+	}
+	if d := NewDPT_7003FromDuration(5 * time.Second); d != 500 {
+		t.Errorf("5s yields \"%v\" units of 10 ms, expected 500.", uint16(d))
+	}
+	if d := NewDPT_7004FromDuration(5 * time.Second); d != 50 {
+		t.Errorf("5s yields \"%v\" units of 100 ms, expected 50.", uint16(d))
+	}
+	if d := NewDPT_7002FromDuration(time.Hour); d != math.MaxUint16 {
+		t.Errorf("1h yields \"%s\", expected clamping to %d ms.", d, math.MaxUint16)
+	}
+	if d := NewDPT_7004FromDuration(-time.Second); d != 0 {
+		t.Errorf("-1s yields \"%s\", expected clamping to 0.", d)
+	}
+}
+
+// Test DPT 7.010 - 7.013 (2-octet unsigned values) with values within range
+func TestDPT_7xxx(t *testing.T) {
+	types := []struct {
+		name string
+		src  func(uint16) DatapointValue
+		get  func(DatapointValue) uint16
+	}{
+		{"DPT_7010", func(v uint16) DatapointValue { d := DPT_7010(v); return &d }, func(d DatapointValue) uint16 { return uint16(*d.(*DPT_7010)) }},
+		{"DPT_7011", func(v uint16) DatapointValue { d := DPT_7011(v); return &d }, func(d DatapointValue) uint16 { return uint16(*d.(*DPT_7011)) }},
+		{"DPT_7012", func(v uint16) DatapointValue { d := DPT_7012(v); return &d }, func(d DatapointValue) uint16 { return uint16(*d.(*DPT_7012)) }},
+		{"DPT_7013", func(v uint16) DatapointValue { d := DPT_7013(v); return &d }, func(d DatapointValue) uint16 { return uint16(*d.(*DPT_7013)) }},
+	}
+
+	for _, typ := range types {
+		for _, value := range []uint16{0, math.MaxUint16, uint16(rand.Uint32()), uint16(rand.Uint32())} {
+			buf := typ.src(value).Pack()
+			dst := typ.src(0)
+			if err := dst.Unpack(buf); err != nil {
+				t.Errorf("Unpacking value \"%v\" for %s failed: %v", value, typ.name, err)
+			}
+			if typ.get(dst) != value {
+				t.Errorf("Wrong value \"%v\" after pack/unpack for %s! Original value was \"%v\".", dst, typ.name, value)
+			}
 		}
+	}
+}
+
+// Test DPT 8.002 (Delta time ms) with values within range
+func TestDPT_8002(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_8002
+
+	for _, value := range []int16{math.MinInt16, -1, 0, 1, math.MaxInt16, int16(rand.Uint32())} {
+		src = DPT_8002(value)
 		buf = src.Pack()
 		dst.Unpack(buf)
-		if abs(float32(dst)-value) > (Q + epsilon) {
-			t.Errorf("Value \"%s\" after pack/unpack above quantization noise! Original value was \"%v\", noise is \"%f\"", dst, value, Q)
+		if int16(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
 		}
+		if dst.Duration() != time.Duration(value)*time.Millisecond {
+			t.Errorf("Wrong duration \"%v\" for value \"%s\".", dst.Duration(), dst)
+		}
+	}
+
+	duration := -1500 * time.Millisecond
+	if d := NewDPT_8002FromDuration(duration); d != -1500 || d.Duration() != duration {
+		t.Errorf("Duration \"%v\" yields \"%s\" and \"%v\".", duration, d, d.Duration())
+	}
+
+	if d := NewDPT_8002FromDuration(time.Minute); d != math.MaxInt16 {
+		t.Errorf("Duration of one minute yields \"%s\", expected clamping to %d ms.", d, math.MaxInt16)
+	}
+	if d := NewDPT_8002FromDuration(-time.Minute); d != math.MinInt16 {
+		t.Errorf("Duration of minus one minute yields \"%s\", expected clamping to %d ms.", d, math.MinInt16)
 	}
 }
 
-// Test DPT 9.004 (Illumination) with values within range
-func TestDPT_9004(t *testing.T) {
+// Test DPT 9.001 (Temperature) with values within range
+func TestDPT_9001(t *testing.T) {
 	var buf []byte
-	var src, dst DPT_9004
+	var src, dst DPT_9001
 
 	for i := 1; i <= 10; i++ {
 		value := rand.Float32()
 
 		// Scale the random number to the given range
-		value *= 670760
+		value *= 670760 - -273
+		value += -273
 
 		// Calculate the quantization error we expect
 		Q := get_float_quantization_error(value, 0.01, 2047)
 
 		// Pack and unpack to test value
-		src = DPT_9004(value)
+		src = DPT_9001(value)
 		if abs(float32(src)-value) > epsilon {
-			t.Errorf("Assignment of value \"%v\" failed for source of type DPT_9004! Has value \"%s\".", value, src)
+			t.Errorf("Assignment of value \"%v\" failed for source of type DPT_9001! Has value \"%s\".", value, src)
 		}
 		buf = src.Pack()
 		dst.Unpack(buf)
@@ -293,35 +887,909 @@ func TestDPT_9004(t *testing.T) {
 	}
 }
 
-// Test DPT 12.001 (Unsigned counter) with values within range
-func TestDPT_12001(t *testing.T) {
-	var buf []byte
-	var src, dst DPT_12001
+// Test DPT 9.001 (Temperature) and 9.004 (Illumination) invalid value marker
+func TestDPT_9xxxInvalid(t *testing.T) {
+	var temp DPT_9001
+	var lux DPT_9004
 
-	for i := 1; i <= 10; i++ {
+	if err := temp.Unpack([]byte{0, 0x7f, 0xff}); err != nil || temp.Valid() {
+		t.Errorf("Invalid marker yields \"%s\" (%v), expected invalid value.", temp, err)
+	}
+	if buf := temp.Pack(); buf[1] != 0x7f || buf[2] != 0xff {
+		t.Errorf("Invalid value packs to %v, expected [0 127 255].", buf)
+	}
+
+	if err := lux.Unpack([]byte{0, 0x7f, 0xff}); err != nil || lux.Valid() {
+		t.Errorf("Invalid marker yields \"%s\" (%v), expected invalid value.", lux, err)
+	}
+	if buf := DPT_9004(math.NaN()).Pack(); buf[1] != 0x7f || buf[2] != 0xff {
+		t.Errorf("Invalid value packs to %v, expected [0 127 255].", buf)
+	}
+
+	if !DPT_9001(21.5).Valid() || !DPT_9004(0).Valid() {
+		t.Errorf("Regular values are reported as invalid.")
+	}
+}
+
+// Test ordering of DPT 9.001 (Temperature) values
+func TestDPT_9001Sort(t *testing.T) {
+	if !DPT_9001(20.5).Less(21) || DPT_9001(21).Less(20.5) || DPT_9001(21).Less(21) {
+		t.Errorf("Less does not order temperatures correctly.")
+	}
+
+	values := DPT_9001Slice{22.5, -3, 19.25, 30, 0}
+	sort.Sort(values)
+
+	expected := []DPT_9001{-3, 0, 19.25, 22.5, 30}
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Errorf("Sorted temperatures are %v, expected %v.", values, expected)
+			break
+		}
+	}
+}
+
+// Test difference of DPT 9.001 (Temperature) values
+func TestDelta(t *testing.T) {
+	if d := Delta(20, 22); d != 2 {
+		t.Errorf("Delta from 20 °C to 22 °C is \"%v\", expected 2 K.", d)
+	}
+	if d := Delta(22, 20.5); d != -1.5 {
+		t.Errorf("Delta from 22 °C to 20.5 °C is \"%v\", expected -1.5 K.", d)
+	}
+	if d := Delta(21.5, DPT_9001(21.5).Shift(-3)); d != -3 {
+		t.Errorf("Delta does not invert Shift: \"%v\".", d)
+	}
+	if d := Delta(invalidTemp, 20); d.Valid() {
+		t.Errorf("Delta from the invalid value is \"%v\".", d)
+	}
+
+	var dst DPT_9002
+	dst.Unpack(Delta(20, 22).Pack())
+	if dst != 2 {
+		t.Errorf("Wrong value \"%v\" after pack/unpack! Original value was 2 K.", dst)
+	}
+}
+
+// Test conversion of DPT 9.001 from and to Kelvin
+func TestDPT_9001Kelvin(t *testing.T) {
+	src := NewDPT_9001FromKelvin(300)
+	if abs(float32(src)-26.85) > epsilon {
+		t.Errorf("300 K yields \"%v\", expected 26.85 °C.", src)
+	}
+
+	// The 2-octet float has a resolution of 0.02 at this magnitude.
+	var dst DPT_9001
+	dst.Unpack(src.Pack())
+	if abs(float32(dst)-26.85) > 0.02 {
+		t.Errorf("Wrong value \"%v\" after pack/unpack! Original value was \"%v\".", dst, src)
+	}
+	if abs(dst.Kelvin()-300) > 0.02 {
+		t.Errorf("Wrong Kelvin value \"%v\" after pack/unpack! Original value was 300 K.", dst.Kelvin())
+	}
+
+	if k := DPT_9001(-273.15).Kelvin(); abs(k) > epsilon {
+		t.Errorf("-273.15 °C yields %v K, expected 0 K.", k)
+	}
+}
+
+// Test classification of DPT 9.001 (Temperature) into comfort bands
+func TestDPT_9001ComfortBand(t *testing.T) {
+	thresholds := []float32{16, 20, 24, 28}
+
+	cases := []struct {
+		temp     DPT_9001
+		expected string
+	}{
+		{-5, "cold"},
+		{15.99, "cold"},
+		{16, "cool"},
+		{19.5, "cool"},
+		{21.5, "comfortable"},
+		{24, "warm"},
+		{27.9, "warm"},
+		{28, "hot"},
+		{35, "hot"},
+	}
+
+	for _, c := range cases {
+		if band := c.temp.ComfortBand(thresholds); band != c.expected {
+			t.Errorf("Wrong band \"%s\" for %v, expected \"%s\".", band, c.temp, c.expected)
+		}
+	}
+
+	if band := invalidTemp.ComfortBand(thresholds); band != "" {
+		t.Errorf("Wrong band \"%s\" for the invalid value.", band)
+	}
+	if band := DPT_9001(21).ComfortBand(thresholds[:3]); band != "" {
+		t.Errorf("Wrong band \"%s\" with three thresholds.", band)
+	}
+}
+
+// Test DPT 9.001 (Temperature) with a device specific range
+func TestClampedDPT_9001(t *testing.T) {
+	src := ClampedDPT_9001{Value: 35, Min: 15, Max: 30}
+
+	var plain DPT_9001
+	plain.Unpack(src.Pack())
+	if plain != 30 {
+		t.Errorf("35 °C packs to \"%v\" with range [15, 30], expected 30 °C.", plain)
+	}
+
+	src.Value = 10
+	plain.Unpack(src.Pack())
+	if plain != 15 {
+		t.Errorf("10 °C packs to \"%v\" with range [15, 30], expected 15 °C.", plain)
+	}
+
+	dst := ClampedDPT_9001{Min: 15, Max: 30}
+	for _, c := range []struct {
+		value      DPT_9001
+		outOfRange bool
+	}{{21.5, false}, {15, false}, {30, false}, {35, true}, {-5, true}, {invalidTemp, false}} {
+		if err := dst.Unpack(c.value.Pack()); err != nil {
+			t.Errorf("Unpacking \"%v\" failed: %v", c.value, err)
+		}
+		if dst.OutOfRange != c.outOfRange || (c.value.Valid() && dst.Value != c.value) {
+			t.Errorf("Unpacking \"%v\" yields \"%+v\".", c.value, dst)
+		}
+	}
+
+	if dst.Min != 15 || dst.Max != 30 {
+		t.Errorf("Unpack changes the range to [%v, %v].", dst.Min, dst.Max)
+	}
+}
+
+// Test DPT 9.002 (Temperature difference) with values within range
+func TestDPT_9002(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_9002
+
+	for i := 1; i <= 10; i++ {
+		value := rand.Float32()
+
+		// Scale the random number to the given range
+		value *= 670760 - -670760
+		value += -670760
+
+		// Calculate the quantization error we expect
+		Q := get_float_quantization_error(abs(value), 0.01, 2047)
+
+		// Pack and unpack to test value
+		src = DPT_9002(value)
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if abs(float32(dst)-value) > (Q + epsilon) {
+			t.Errorf("Value \"%s\" after pack/unpack above quantization noise! Original value was \"%v\", noise is \"%f\"", dst, value, Q)
+		}
+	}
+}
+
+// Test shifting a DPT 9.001 (Temperature) setpoint by a DPT 9.002 (Temperature difference)
+func TestDPT_9001Shift(t *testing.T) {
+	var dst DPT_9001
+
+	setpoint := DPT_9001(21).Shift(1.5)
+	if setpoint != 22.5 {
+		t.Errorf("21 °C shifted by 1.5 K yields \"%s\", expected 22.5 °C.", setpoint)
+	}
+
+	dst.Unpack(setpoint.Pack())
+	if dst != setpoint {
+		t.Errorf("Shifted setpoint \"%s\" is not representable, unpacks to \"%s\".", setpoint, dst)
+	}
+
+	if setpoint := DPT_9001(21).Shift(-2); setpoint != 19 {
+		t.Errorf("21 °C shifted by -2 K yields \"%s\", expected 19 °C.", setpoint)
+	}
+}
+
+// Test DPT 9.004 (Illumination) with values within range
+func TestDPT_9004(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_9004
+
+	for i := 1; i <= 10; i++ {
+		value := rand.Float32()
+
+		// Scale the random number to the given range
+		value *= 670760
+
+		// Calculate the quantization error we expect
+		Q := get_float_quantization_error(value, 0.01, 2047)
+
+		// Pack and unpack to test value
+		src = DPT_9004(value)
+		if abs(float32(src)-value) > epsilon {
+			t.Errorf("Assignment of value \"%v\" failed for source of type DPT_9004! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if abs(float32(dst)-value) > (Q + epsilon) {
+			t.Errorf("Value \"%s\" after pack/unpack above quantization noise! Original value was \"%v\", noise is \"%f\"", dst, value, Q)
+		}
+	}
+}
+
+// Test DPT 9.004 (Illumination) conversion to exposure value
+func TestDPT_9004ExposureValue(t *testing.T) {
+	cases := []struct {
+		lux DPT_9004
+		ev  float32
+	}{
+		{2.5, 0},
+		{2500, 9.966},
+		{40960, 14},
+	}
+
+	for _, c := range cases {
+		if ev := c.lux.ExposureValue(); abs(ev-c.ev) > 0.01 {
+			t.Errorf("\"%s\" yields EV %f, expected %f.", c.lux, ev, c.ev)
+		}
+	}
+}
+
+// Test unpacking of DPT 9.xxx frames with status octet
+func TestF16UnpackWithStatus(t *testing.T) {
+	var temp DPT_9001
+
+	fault, err := temp.UnpackWithStatus([]byte{0, 0x0c, 0x33})
+	if err != nil || fault || temp != 21.5 {
+		t.Errorf("Unpacking standard frame yields (%v, %v, %v)", temp, fault, err)
+	}
+
+	fault, err = temp.UnpackWithStatus([]byte{0, 0x0c, 0x1a, 0x02})
+	if err != nil || !fault || temp != 21 {
+		t.Errorf("Unpacking frame with fault status yields (%v, %v, %v)", temp, fault, err)
+	}
+
+	fault, err = temp.UnpackWithStatus([]byte{0, 0x0c, 0x33, 0x01})
+	if err != nil || fault || temp != 21.5 {
+		t.Errorf("Unpacking frame with out of service status yields (%v, %v, %v)", temp, fault, err)
+	}
+
+	var lux DPT_9004
+	fault, err = lux.UnpackWithStatus([]byte{0, 0x7f, 0xff, 0x02})
+	if err != nil || !fault || lux.Valid() {
+		t.Errorf("Unpacking invalid value with fault status yields (%v, %v, %v)", lux, fault, err)
+	}
+
+	var shift DPT_9002
+	for _, data := range [][]byte{{0, 0x0c}, {0, 0x0c, 0x33, 0, 0}} {
+		if _, err := shift.UnpackWithStatus(data); err != ErrInvalidLength {
+			t.Errorf("Unpacking %v yields %v instead of ErrInvalidLength", data, err)
+		}
+	}
+}
+
+// Test conversion between DPT 7.013 and DPT 9.004
+func TestIlluminanceConversion(t *testing.T) {
+	if lux := DPT_7013(1000).To9004(); lux != 1000 {
+		t.Errorf("1000 lx (7.013) yields \"%v\" as 9.004.", lux)
+	}
+	if lux := DPT_9004(1000).To7013(); lux != 1000 {
+		t.Errorf("1000 lx (9.004) yields \"%v\" as 7.013.", lux)
+	}
+
+	cases := []struct {
+		value    DPT_9004
+		expected DPT_7013
+	}{
+		{999.6, 1000},
+		{0.4, 0},
+		{-5, 0},
+		{100000, 65535},
+		{DPT_9004(math.NaN()), 0},
+	}
+
+	for _, c := range cases {
+		if lux := c.value.To7013(); lux != c.expected {
+			t.Errorf("\"%v\" yields \"%v\" as 7.013, expected \"%v\".", c.value, lux, c.expected)
+		}
+	}
+}
+
+// Test DPT 9.007 (Humidity) with values within range
+func TestDPT_9007(t *testing.T) {
+	var dst DPT_9007
+
+	for i := 0; i < 100; i++ {
+		src := DPT_9007(rand.Float32() * 100)
+		if err := dst.Unpack(src.Pack()); err != nil {
+			t.Errorf("Unpacking \"%v\" failed: %v", src, err)
+		}
+		if abs(float32(dst-src)) > 0.08 {
+			t.Errorf("Wrong value \"%v\" after pack/unpack! Original value was \"%v\".", dst, src)
+		}
+	}
+
+	dst.Unpack(DPT_9007(-5).Pack())
+	if dst != 0 {
+		t.Errorf("Negative humidity packs to \"%v\", expected 0%%.", dst)
+	}
+
+	dst.Unpack(DPT_9007(math.NaN()).Pack())
+	if dst.Valid() {
+		t.Errorf("Invalid value unpacks to \"%v\".", dst)
+	}
+
+	if err := dst.Unpack([]byte{0, 0x80, 0x01}); err == nil {
+		t.Errorf("Negative humidity unpacks to \"%v\".", dst)
+	}
+
+	if s := fmt.Sprintf("%+v", DPT_9007(55.5)); s != "55.50% (DPT 9.007)" {
+		t.Errorf("Wrong format \"%s\".", s)
+	}
+}
+
+// Test quantization of scaling and 2-octet float values
+func TestQuantize(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		f := rand.Float32()
+
+		scaling := DPT_5001(f * 100).Quantize()
+		if scaling.Quantize() != scaling || abs(float32(scaling)-f*100) > float32(100)/255 {
+			t.Errorf("Quantizing \"%v\" yields \"%v\".", f*100, scaling)
+		}
+
+		angle := DPT_5003(f * 360).Quantize()
+		if angle.Quantize() != angle || angle.Pack()[1] != DPT_5003(f * 360).Pack()[1]%255 {
+			t.Errorf("Quantizing \"%v\" yields \"%v\".", f*360, angle)
+		}
+
+		temp := DPT_9001(f*100 - 20).Quantize()
+		if temp.Quantize() != temp || !bytes.Equal(temp.Pack(), DPT_9001(f*100-20).Pack()) {
+			t.Errorf("Quantizing \"%v\" yields \"%v\".", f*100-20, temp)
+		}
+
+		shift := DPT_9002(f*10 - 5).Quantize()
+		if shift.Quantize() != shift {
+			t.Errorf("Quantizing \"%v\" yields \"%v\".", f*10-5, shift)
+		}
+
+		lux := DPT_9004(f * 100000).Quantize()
+		if lux.Quantize() != lux {
+			t.Errorf("Quantizing \"%v\" yields \"%v\".", f*100000, lux)
+		}
+	}
+
+	// Quantization is idempotent for every value that can be received.
+	for octet := 0; octet <= 255; octet++ {
+		var scaling DPT_5001
+		scaling.Unpack([]byte{0, uint8(octet)})
+		if q := scaling.Quantize(); q.Quantize() != q {
+			t.Errorf("Quantizing \"%v\" yields \"%v\", which quantizes to \"%v\".", scaling, q, q.Quantize())
+		}
+	}
+	for code := 0; code <= 0xffff; code++ {
+		var temp DPT_9001
+		temp.Unpack([]byte{0, uint8(code >> 8), uint8(code)})
+		if q := temp.Quantize(); q.Quantize() != q && q.Valid() {
+			t.Errorf("Quantizing \"%v\" yields \"%v\", which quantizes to \"%v\".", temp, q, q.Quantize())
+		}
+	}
+
+	if q := DPT_9001(21.5).Quantize(); q != 21.5 {
+		t.Errorf("Quantizing 21.5 yields \"%v\".", q)
+	}
+	if q := DPT_9001(math.NaN()).Quantize(); q.Valid() {
+		t.Errorf("Quantizing the invalid value yields \"%v\".", q)
+	}
+
+	// Values outside of the range of the type are clamped like when packing.
+	if q := DPT_9001(-300).Quantize(); q < -273 || !bytes.Equal(q.Pack(), DPT_9001(-300).Pack()) {
+		t.Errorf("Quantizing -300 °C yields \"%v\".", q)
+	}
+	if q := DPT_9002(-700000).Quantize(); q < -670760 || !bytes.Equal(q.Pack(), DPT_9002(-700000).Pack()) {
+		t.Errorf("Quantizing -700000 K yields \"%v\".", q)
+	}
+	if q := DPT_9004(-5).Quantize(); q != 0 {
+		t.Errorf("Quantizing -5 lx yields \"%v\", expected 0.", q)
+	}
+	if q := DPT_9007(-1).Quantize(); q != 0 {
+		t.Errorf("Quantizing -1%% yields \"%v\", expected 0.", q)
+	}
+}
+
+// Test arithmetic of 2-octet float values
+func TestF16Arithmetic(t *testing.T) {
+	var temp DPT_9001
+	for i := 0; i < 100; i++ {
+		temp = temp.Add(0.01)
+	}
+	if temp != 1 || !bytes.Equal(temp.Pack(), []byte{0, 0x00, 0x64}) {
+		t.Errorf("Adding 0.01 a hundred times yields \"%v\" (%v), expected 1.", temp, temp.Pack())
+	}
+
+	for i := 0; i < 100; i++ {
+		temp = temp.Sub(0.01)
+	}
+	if temp != 0 {
+		t.Errorf("Subtracting 0.01 a hundred times yields \"%v\", expected 0.", temp)
+	}
+
+	if v := DPT_9001(21.5).Add(0.004); v != 21.5 {
+		t.Errorf("Adding less than the resolution yields \"%v\".", v)
+	}
+	if v := DPT_9002(-1).Sub(0.5); v != -1.5 {
+		t.Errorf("Subtracting 0.5 K from -1 K yields \"%v\".", v)
+	}
+	if v := DPT_9004(400).Add(200); v != 600 {
+		t.Errorf("Adding 200 lx to 400 lx yields \"%v\".", v)
+	}
+	if v := invalidTemp.Add(1); v.Valid() {
+		t.Errorf("Adding to the invalid value yields \"%v\".", v)
+	}
+	if v := DPT_9004(670000).Add(760); !v.Valid() || !bytes.Equal(v.Pack(), []byte{0, 0x7f, 0xfe}) {
+		t.Errorf("Adding up to the upper limit yields \"%v\" (%v).", v, v.Pack())
+	}
+	if v := DPT_9001(670700).Sub(0); !v.Valid() || !bytes.Equal(v.Pack(), DPT_9001(670700).Pack()) {
+		t.Errorf("Subtracting 0 from 670700 °C yields \"%v\" (%v).", v, v.Pack())
+	}
+	if v := DPT_9001(-273).Sub(10); !bytes.Equal(v.Pack(), DPT_9001(-273).Pack()) {
+		t.Errorf("Subtracting 10 K from -273 °C yields \"%v\", expected the lower limit.", v)
+	}
+	if v := DPT_9004(5).Sub(10); v != 0 {
+		t.Errorf("Subtracting 10 lx from 5 lx yields \"%v\", expected 0.", v)
+	}
+}
+
+// Test decoding F16 values to float64
+func TestF16Float64(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		value := DPT_9001(rand.Float32()*940 - 270)
+
+		var f32 DPT_9001
+		f32.Unpack(value.Pack())
+
+		f64 := value.Float64()
+		if math.Abs(f64-float64(f32)) > 1e-4*math.Max(1, math.Abs(f64)) {
+			t.Errorf("Float64 value \"%v\" differs from float32 value \"%v\".", f64, f32)
+		}
+	}
+
+	// Both decoders agree on every code, including the invalid value marker.
+	for code := 0; code <= 0xffff; code++ {
+		data := []byte{0, uint8(code >> 8), uint8(code)}
+
+		var f32 float32
+		var f64 float64
+		unpackF16(data, &f32)
+		unpackF16Float64(data, &f64)
+		if f32 != float32(f64) && (f32 == f32 || f64 == f64) {
+			t.Errorf("Code %#04x decodes to \"%v\" and \"%v\".", code, f32, f64)
+		}
+	}
+
+	if v := DPT_9001(0.1).Float64(); v != 0.1 {
+		t.Errorf("Wrong float64 value \"%v\" for 0.1.", v)
+	}
+	if v := DPT_9002(-1.5).Float64(); v != -1.5 {
+		t.Errorf("Wrong float64 value \"%v\" for -1.5.", v)
+	}
+	if v := DPT_9004(40960).Float64(); v != 40960 {
+		t.Errorf("Wrong float64 value \"%v\" for 40960.", v)
+	}
+	if v := DPT_9001(math.NaN()).Float64(); v == v {
+		t.Errorf("Invalid value decoded to \"%v\" instead of NaN.", v)
+	}
+}
+
+// Test DPT 10.001 (Time of day) with values within range
+func TestDPT_10001(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_10001
+
+	for i := 1; i <= 10; i++ {
+		src = DPT_10001{
+			Weekday: uint8(rand.Intn(8)),
+			Hour:    uint8(rand.Intn(24)),
+			Minutes: uint8(rand.Intn(60)),
+			Seconds: uint8(rand.Intn(60)),
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if dst != src {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%s\".", dst, src)
+		}
+	}
+
+	if err := dst.Unpack([]byte{0, 24, 0, 0}); err == nil {
+		t.Errorf("Unpacking hour 24 should fail.")
+	}
+}
+
+// Test DPT 10.001 (Time of day) comparison
+func TestDPT_10001Compare(t *testing.T) {
+	start := DPT_10001{Weekday: 1, Hour: 8}
+	end := DPT_10001{Weekday: 1, Hour: 17, Minutes: 30}
+
+	if d := end.Sub(start); d != 9*time.Hour+30*time.Minute {
+		t.Errorf("17:30 - 08:00 yields %v, expected 9h30m.", d)
+	}
+	if d := start.Sub(end); d != -(9*time.Hour + 30*time.Minute) {
+		t.Errorf("08:00 - 17:30 yields %v, expected -9h30m.", d)
+	}
+
+	if !start.Before(end) || start.After(end) || !end.After(start) || end.Before(start) {
+		t.Errorf("08:00 and 17:30 are not ordered correctly.")
+	}
+
+	// The weekday does not take part in the comparison.
+	other := DPT_10001{Weekday: 5, Hour: 8}
+	if start.Sub(other) != 0 || start.Before(other) || start.After(other) {
+		t.Errorf("Times on different weekdays are not compared by time of day.")
+	}
+}
+
+// Test DPT 12.001 (Unsigned counter) with values within range
+func TestDPT_12001(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_12001
+
+	for i := 1; i <= 10; i++ {
 		value := rand.Uint32()
 
-		// Pack and unpack to test value
-		src = DPT_12001(value)
-		if uint32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed for source of type DPT_12001! Has value \"%s\".", value, src)
+		// Pack and unpack to test value
+		src = DPT_12001(value)
+		if uint32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed for source of type DPT_12001! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if uint32(dst) != value {
+			t.Errorf("Value \"%s\" after pack/unpack different from Original value. Was \"%v\"", dst, value)
+		}
+	}
+}
+
+// Test DPT 12.001 (Unsigned counter) handling of the leading octet
+func TestDPT_12001LeadingOctet(t *testing.T) {
+	var dst DPT_12001
+
+	defer func(strict bool) { StrictLeadingOctet = strict }(StrictLeadingOctet)
+
+	if buf := DPT_12001(0xDEADBEEF).Pack(); buf[0] != 0 {
+		t.Errorf("Packed leading octet is %#02x, expected 0x00.", buf[0])
+	}
+
+	for _, strict := range []bool{false, true} {
+		StrictLeadingOctet = strict
+
+		if err := dst.Unpack([]byte{0x00, 0xDE, 0xAD, 0xBE, 0xEF}); err != nil || dst != 0xDEADBEEF {
+			t.Errorf("Leading octet 0x00 yields \"%s\" (%v) in strict mode %v.", dst, err, strict)
+		}
+	}
+
+	StrictLeadingOctet = false
+	if err := dst.Unpack([]byte{0x80, 0xDE, 0xAD, 0xBE, 0xEF}); err != nil || dst != 0xDEADBEEF {
+		t.Errorf("Leading octet 0x80 yields \"%s\" (%v), expected it to be ignored.", dst, err)
+	}
+
+	StrictLeadingOctet = true
+	if err := dst.Unpack([]byte{0x80, 0xDE, 0xAD, 0xBE, 0xEF}); err != ErrInvalidLeadingOctet {
+		t.Errorf("Leading octet 0x80 should fail with ErrInvalidLeadingOctet in strict mode, got %v.", err)
+	}
+}
+
+// Test wraparound of DPT 12.001 (counter pulses) increments
+func TestDPT_12001Inc(t *testing.T) {
+	if v := DPT_12001(41).Inc(1); v != 42 {
+		t.Errorf("Incrementing 41 by 1 yields \"%v\".", v)
+	}
+	if v := DPT_12001(math.MaxUint32).Inc(1); v != 0 {
+		t.Errorf("Incrementing the maximum by 1 yields \"%v\", expected 0.", v)
+	}
+	if v := DPT_12001(math.MaxUint32 - 1).Inc(5); v != 3 {
+		t.Errorf("Incrementing the maximum - 1 by 5 yields \"%v\", expected 3.", v)
+	}
+	if v := DPT_12001(7).Inc(0); v != 7 {
+		t.Errorf("Incrementing 7 by 0 yields \"%v\".", v)
+	}
+}
+
+// Test DPT 13.001 (counter pulses)
+func TestDPT_13001(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_13001
+
+	// Corner cases
+	for _, value := range []int32{math.MinInt32, math.MaxInt32} {
+		src = DPT_13001(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	// Positive
+	for i := 1; i <= 10; i++ {
+		value := rand.Int31()
+
+		src = DPT_13001(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	// Negative
+	for i := 1; i <= 10; i++ {
+		value := -rand.Int31()
+
+		src = DPT_13001(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+}
+
+// Test DPT 13.001 (counter pulses) delta computation
+func TestAddDelta(t *testing.T) {
+	cases := []struct {
+		prev, cur DPT_13001
+		delta     int64
+		wrapped   bool
+	}{
+		{100, 150, 50, false},
+		{150, 100, -50, false},
+		{math.MaxInt32 - 5, math.MaxInt32, 5, false},
+		{math.MaxInt32 - 5, math.MinInt32 + 4, 10, true},
+		{math.MaxInt32, math.MinInt32, 1, true},
+		{math.MinInt32 + 4, math.MaxInt32 - 5, -10, true},
+	}
+
+	for _, c := range cases {
+		delta, wrapped := AddDelta(c.prev, c.cur)
+		if delta != c.delta || wrapped != c.wrapped {
+			t.Errorf("AddDelta(%d, %d) = (%d, %v), expected (%d, %v)",
+				c.prev, c.cur, delta, wrapped, c.delta, c.wrapped)
+		}
+	}
+}
+
+// Test DPT 13.002 (flow rate)
+func TestDPT_13002(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_13002
+
+	// Corner cases
+	for _, value := range []int32{math.MinInt32, math.MaxInt32} {
+		src = DPT_13002(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	// Positive
+	for i := 1; i <= 10; i++ {
+		value := rand.Int31()
+
+		src = DPT_13002(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	// Negative
+	for i := 1; i <= 10; i++ {
+		value := -rand.Int31()
+
+		src = DPT_13002(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+}
+
+// Test DPT 13.010 (active energy)
+func TestDPT_13010(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_13010
+
+	// Corner cases
+	for _, value := range []int32{math.MinInt32, math.MaxInt32} {
+		src = DPT_13010(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	// Positive
+	for i := 1; i <= 10; i++ {
+		value := rand.Int31()
+
+		src = DPT_13010(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	// Negative
+	for i := 1; i <= 10; i++ {
+		value := -rand.Int31()
+
+		src = DPT_13010(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+}
+
+// Test conversion of DPT 13.010 to kilowatt hours
+func TestDPT_13010KilowattHours(t *testing.T) {
+	cases := []struct {
+		value    DPT_13010
+		mode     RoundMode
+		expected float64
+	}{
+		{3600500, RoundFloor, 3600},
+		{3600500, RoundNearest, 3601},
+		{3600500, RoundCeil, 3601},
+		{3600499, RoundNearest, 3600},
+		{3600001, RoundCeil, 3601},
+		{3600000, RoundCeil, 3600},
+		{-1500, RoundFloor, -2},
+		{-1500, RoundNearest, -2},
+		{-1500, RoundCeil, -1},
+	}
+
+	for _, c := range cases {
+		if kwh := c.value.KilowattHours(c.mode); kwh != c.expected {
+			t.Errorf("Wrong value \"%v\" for %s with mode %d! Expected \"%v\".", kwh, c.value, c.mode, c.expected)
+		}
+	}
+}
+
+// Test DPT 13.011 (apparant energy)
+func TestDPT_13011(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_13011
+
+	// Corner cases
+	for _, value := range []int32{math.MinInt32, math.MaxInt32} {
+		src = DPT_13011(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	// Positive
+	for i := 1; i <= 10; i++ {
+		value := rand.Int31()
+
+		src = DPT_13011(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	// Negative
+	for i := 1; i <= 10; i++ {
+		value := -rand.Int31()
+
+		src = DPT_13011(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+}
+
+// Test DPT 13.012 (reactive energy)
+func TestDPT_13012(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_13012
+
+	// Corner cases
+	for _, value := range []int32{math.MinInt32, math.MaxInt32} {
+		src = DPT_13012(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	// Positive
+	for i := 1; i <= 10; i++ {
+		value := rand.Int31()
+
+		src = DPT_13012(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
 		}
 		buf = src.Pack()
 		dst.Unpack(buf)
-		if uint32(dst) != value {
-			t.Errorf("Value \"%s\" after pack/unpack different from Original value. Was \"%v\"", dst, value)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	// Negative
+	for i := 1; i <= 10; i++ {
+		value := -rand.Int31()
+
+		src = DPT_13012(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
 		}
 	}
 }
 
-// Test DPT 13.001 (counter pulses)
-func TestDPT_13001(t *testing.T) {
+// Test DPT 13.013 (active energy (kWh))
+func TestDPT_13013(t *testing.T) {
 	var buf []byte
-	var src, dst DPT_13001
+	var src, dst DPT_13013
 
 	// Corner cases
 	for _, value := range []int32{math.MinInt32, math.MaxInt32} {
-		src = DPT_13001(value)
+		src = DPT_13013(value)
 		if int32(src) != value {
 			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
 		}
@@ -336,7 +1804,7 @@ func TestDPT_13001(t *testing.T) {
 	for i := 1; i <= 10; i++ {
 		value := rand.Int31()
 
-		src = DPT_13001(value)
+		src = DPT_13013(value)
 		if int32(src) != value {
 			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
 		}
@@ -351,7 +1819,7 @@ func TestDPT_13001(t *testing.T) {
 	for i := 1; i <= 10; i++ {
 		value := -rand.Int31()
 
-		src = DPT_13001(value)
+		src = DPT_13013(value)
 		if int32(src) != value {
 			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
 		}
@@ -363,14 +1831,34 @@ func TestDPT_13001(t *testing.T) {
 	}
 }
 
-// Test DPT 13.002 (flow rate)
-func TestDPT_13002(t *testing.T) {
+// Test cost calculation of DPT 13.013
+func TestDPT_13013Cost(t *testing.T) {
+	cases := []struct {
+		value    DPT_13013
+		rate     float64
+		expected float64
+	}{
+		{1200, 0.25, 300},
+		{3, 0.3, 0.9},
+		{0, 0.25, 0},
+		{-400, 0.1, -40}, // Energy fed back into the grid
+	}
+
+	for _, c := range cases {
+		if cost := c.value.Cost(c.rate); math.Abs(cost-c.expected) > epsilon {
+			t.Errorf("Wrong cost \"%v\" for %s at rate %v! Expected \"%v\".", cost, c.value, c.rate, c.expected)
+		}
+	}
+}
+
+// Test DPT 13.014 (apparant energy (kVAh))
+func TestDPT_13014(t *testing.T) {
 	var buf []byte
-	var src, dst DPT_13002
+	var src, dst DPT_13014
 
 	// Corner cases
 	for _, value := range []int32{math.MinInt32, math.MaxInt32} {
-		src = DPT_13002(value)
+		src = DPT_13014(value)
 		if int32(src) != value {
 			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
 		}
@@ -385,7 +1873,7 @@ func TestDPT_13002(t *testing.T) {
 	for i := 1; i <= 10; i++ {
 		value := rand.Int31()
 
-		src = DPT_13002(value)
+		src = DPT_13014(value)
 		if int32(src) != value {
 			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
 		}
@@ -400,7 +1888,7 @@ func TestDPT_13002(t *testing.T) {
 	for i := 1; i <= 10; i++ {
 		value := -rand.Int31()
 
-		src = DPT_13002(value)
+		src = DPT_13014(value)
 		if int32(src) != value {
 			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
 		}
@@ -412,14 +1900,44 @@ func TestDPT_13002(t *testing.T) {
 	}
 }
 
-// Test DPT 13.010 (active energy)
-func TestDPT_13010(t *testing.T) {
+// Test DPT 13.015 (reactive energy (kVARh))
+func TestDPT_13015(t *testing.T) {
 	var buf []byte
-	var src, dst DPT_13010
+	var src, dst DPT_13015
 
 	// Corner cases
 	for _, value := range []int32{math.MinInt32, math.MaxInt32} {
-		src = DPT_13010(value)
+		src = DPT_13015(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	// Positive
+	for i := 1; i <= 10; i++ {
+		value := rand.Int31()
+
+		src = DPT_13015(value)
+		if int32(src) != value {
+			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+		}
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	// Negative
+	for i := 1; i <= 10; i++ {
+		value := -rand.Int31()
+
+		src = DPT_13015(value)
 		if int32(src) != value {
 			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
 		}
@@ -429,279 +1947,777 @@ func TestDPT_13010(t *testing.T) {
 			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
 		}
 	}
+}
+
+// Test DPT 13.100 (Delta time s)
+func TestDPT_13100(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_13100
+
+	for _, value := range []int32{math.MinInt32, math.MinInt32 + 1, -1, 0, 1, math.MaxInt32, rand.Int31(), -rand.Int31()} {
+		src = DPT_13100(value)
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if int32(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+		if dst.Duration() != time.Duration(value)*time.Second {
+			t.Errorf("Wrong duration \"%v\" for value \"%s\".", dst.Duration(), dst)
+		}
+	}
+
+	if d := DPT_13100(math.MinInt32).Duration(); d >= 0 || d != -2147483648*time.Second {
+		t.Errorf("Minimum value yields duration %v.", d)
+	}
+
+	duration := -90 * time.Minute
+	if d := NewDPT_13100FromDuration(duration); d != -5400 || d.Duration() != duration {
+		t.Errorf("Duration \"%v\" yields \"%s\" and \"%v\".", duration, d, d.Duration())
+	}
+
+	if d := NewDPT_13100FromDuration((math.MinInt32 - 10) * time.Second); d != math.MinInt32 {
+		t.Errorf("Duration below range yields \"%s\", expected clamping to %d s.", d, math.MinInt32)
+	}
+	if d := NewDPT_13100FromDuration((math.MaxInt32 + 10) * time.Second); d != math.MaxInt32 {
+		t.Errorf("Duration above range yields \"%s\", expected clamping to %d s.", d, math.MaxInt32)
+	}
+}
+
+// Test DPT 14.002, 14.003, 14.056 and 14.065 (4-octet float values) with values within range
+func TestDPT_14xxx(t *testing.T) {
+	values := []float32{
+		0, 1, -1, 9.81, -9.81,
+		math.MaxFloat32, -math.MaxFloat32,
+		math.SmallestNonzeroFloat32, 1e-30, -1e30,
+		rand.Float32() * 1e6, -rand.Float32() * 1e6,
+	}
+
+	for _, value := range values {
+		var acc DPT_14002
+		acc.Unpack(DPT_14002(value).Pack())
+		if float32(acc) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack for DPT_14002! Original value was \"%v\".", acc, value)
+		}
+
+		var angular DPT_14003
+		angular.Unpack(DPT_14003(value).Pack())
+		if float32(angular) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack for DPT_14003! Original value was \"%v\".", angular, value)
+		}
+
+		var power DPT_14056
+		power.Unpack(DPT_14056(value).Pack())
+		if float32(power) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack for DPT_14056! Original value was \"%v\".", power, value)
+		}
+
+		var speed DPT_14065
+		speed.Unpack(DPT_14065(value).Pack())
+		if float32(speed) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack for DPT_14065! Original value was \"%v\".", speed, value)
+		}
+	}
+
+	if buf := DPT_14065(1).Pack(); len(buf) != 5 || buf[1] != 0x3F || buf[2] != 0x80 || buf[3] != 0 || buf[4] != 0 {
+		t.Errorf("1 m/s packs to %v, expected [0 63 128 0 0].", buf)
+	}
+}
+
+// Test DPT 14.006 (Angle rad) and 14.007 (Angle °) conversion
+func TestDPT_1400xAngle(t *testing.T) {
+	var rad DPT_14006
+	var deg DPT_14007
+
+	rad.Unpack(DPT_14006(math.Pi).Pack())
+	if float32(rad) != float32(math.Pi) {
+		t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", rad, math.Pi)
+	}
+
+	deg.Unpack(DPT_14007(-45.5).Pack())
+	if deg != -45.5 {
+		t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"-45.5\".", deg)
+	}
+
+	if r := DPT_14007(180).Radians(); abs(float32(r)-math.Pi) > epsilon {
+		t.Errorf("180° yields \"%s\", expected π.", r)
+	}
+	if d := DPT_14006(math.Pi).Degrees(); abs(float32(d)-180) > epsilon {
+		t.Errorf("π yields \"%s\", expected 180°.", d)
+	}
+	if d := DPT_14006(-math.Pi / 2).Degrees(); abs(float32(d)+90) > epsilon {
+		t.Errorf("-π/2 yields \"%s\", expected -90°.", d)
+	}
+}
+
+// Test DPT 14.056 (Power) conversion to kW and MW
+func TestDPT_14056Units(t *testing.T) {
+	if mw := DPT_14056(1500000).Megawatts(); abs(mw-1.5) > epsilon {
+		t.Errorf("1500000 W yields %f MW, expected 1.5 MW.", mw)
+	}
+	if kw := DPT_14056(1500000).Kilowatts(); abs(kw-1500) > epsilon {
+		t.Errorf("1500000 W yields %f kW, expected 1500 kW.", kw)
+	}
+	if kw := DPT_14056(-250).Kilowatts(); abs(kw+0.25) > epsilon {
+		t.Errorf("-250 W yields %f kW, expected -0.25 kW.", kw)
+	}
+}
+
+// Test DPT 14.065 (Speed) conversion from and to km/h
+func TestDPT_14065KilometersPerHour(t *testing.T) {
+	if kmh := DPT_14065(10).KilometersPerHour(); abs(kmh-36) > epsilon {
+		t.Errorf("10 m/s yields %f km/h, expected 36 km/h.", kmh)
+	}
+
+	if speed := NewDPT_14065FromKilometersPerHour(-72); abs(float32(speed)+20) > epsilon {
+		t.Errorf("-72 km/h yields \"%s\", expected -20 m/s.", speed)
+	}
+}
+
+// Test DPT 15.000 (Access Data) with values within range
+func TestDPT_15000(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_15000
+
+	for i := 1; i <= 10; i++ {
+		src = DPT_15000{
+			AccessCode:    uint32(rand.Intn(1000000)),
+			Error:         rand.Intn(2) == 1,
+			Permission:    rand.Intn(2) == 1,
+			ReadDirection: rand.Intn(2) == 1,
+			Encryption:    rand.Intn(2) == 1,
+			Index:         uint8(rand.Intn(16)),
+		}
+		buf = src.Pack()
+		if err := dst.Unpack(buf); err != nil {
+			t.Errorf("Unpacking value \"%s\" failed: %v", src, err)
+		}
+		if dst != src {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%s\".", dst, src)
+		}
+	}
+
+	// Sample frame: code 123456, permission granted, read direction set, index 3
+	if err := dst.Unpack([]byte{0x00, 0x12, 0x34, 0x56, 0x63}); err != nil {
+		t.Errorf("Unpacking sample frame failed: %v", err)
+	}
+	expected := DPT_15000{AccessCode: 123456, Permission: true, ReadDirection: true, Index: 3}
+	if dst != expected {
+		t.Errorf("Sample frame yields \"%s\", expected \"%s\".", dst, expected)
+	}
+	if buf = expected.Pack(); !bytes.Equal(buf, []byte{0x00, 0x12, 0x34, 0x56, 0x63}) {
+		t.Errorf("Sample value packs to %v.", buf)
+	}
+
+	if err := dst.Unpack([]byte{0x00, 0x1A, 0x34, 0x56, 0x00}); err == nil {
+		t.Errorf("Unpacking an invalid BCD digit should fail.")
+	}
+}
+
+// Test DPT 16.000 (ASCII String) with null and space padding
+func TestDPT_16000(t *testing.T) {
+	var buf []byte
+	var dst DPT_16000
+
+	for _, value := range []string{"", "KNX", "Hello, World!", "14 characters!"} {
+		src := DPT_16000(value)
+
+		buf = src.Pack()
+		if len(buf) != 15 {
+			t.Errorf("Packed value \"%s\" has invalid length %d.", src, len(buf))
+		}
+		for _, b := range buf[1+len(value):] {
+			if b != 0 {
+				t.Errorf("Packed value \"%s\" is not null-padded: %v", src, buf)
+				break
+			}
+		}
+		dst.Unpack(buf)
+		if string(dst) != value {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+
+		buf = src.PackPadded(' ')
+		for _, b := range buf[1+len(value):] {
+			if b != ' ' {
+				t.Errorf("Packed value \"%s\" is not space-padded: %v", src, buf)
+				break
+			}
+		}
+		dst.Unpack(buf)
+		if string(dst) != value {
+			t.Errorf("Wrong value \"%s\" after space-padded pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	// Frames from devices which mix null and space padding.
+	if err := dst.Unpack([]byte{0, 'O', 'K', ' ', ' ', 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}); err != nil || dst != "OK" {
+		t.Errorf("Mixed padding yields \"%s\" (%v), expected \"OK\".", dst, err)
+	}
+
+	if err := dst.Unpack([]byte{0, 'O', 'K'}); err != ErrInvalidLength {
+		t.Errorf("Unpacking short frame should fail with ErrInvalidLength, got %v", err)
+	}
+}
+
+// Test detection of truncation for DPT 16.000
+func TestDPT_16000WouldTruncate(t *testing.T) {
+	for _, value := range []string{"", "KNX", "14 characters!"} {
+		if DPT_16000(value).WouldTruncate() {
+			t.Errorf("Value \"%s\" is reported to be truncated.", value)
+		}
+	}
+
+	src := DPT_16000("Twenty characters!!!")
+	if !src.WouldTruncate() {
+		t.Errorf("Value \"%s\" is not reported to be truncated.", src)
+	}
+
+	var dst DPT_16000
+	dst.Unpack(src.Pack())
+	if string(dst) != "Twenty charact" {
+		t.Errorf("Wrong value \"%s\" after pack/unpack of truncated value \"%s\".", dst, src)
+	}
+}
+
+// Test justification of DPT 16.000
+func TestNewDPT_16000Justified(t *testing.T) {
+	cases := []struct {
+		s        string
+		width    int
+		right    bool
+		expected DPT_16000
+	}{
+		{"42", 6, true, "    42"},
+		{"42", 6, false, "42    "},
+		{"21.5 C", 14, true, "        21.5 C"},
+		{"12345678", 4, true, "1234"},
+		{"7", 20, true, "             7"},
+		{"7", 0, false, ""},
+	}
+
+	for _, c := range cases {
+		if v := NewDPT_16000Justified(c.s, c.width, c.right); v != c.expected {
+			t.Errorf("Justifying \"%s\" to %d yields \"%s\", expected \"%s\".", c.s, c.width, v, c.expected)
+		}
+	}
+
+	// Leading spaces of right-justified values survive a round trip.
+	var dst DPT_16000
+	dst.Unpack(NewDPT_16000Justified("42", 14, true).Pack())
+	if dst != "            42" {
+		t.Errorf("Wrong value \"%s\" after pack/unpack of right-justified value.", dst)
+	}
+}
+
+// Test DPT 18.001 (Scene Control) with values within range
+func TestDPT_18001(t *testing.T) {
+	var dst DPT_18001
+
+	for _, learn := range []bool{true, false} {
+		for scene := uint8(0); scene < 64; scene++ {
+			src := DPT_18001{Learn: learn, Scene: scene}
+			dst.Unpack(src.Pack())
+			if dst != src {
+				t.Errorf("Wrong value \"%v\" after pack/unpack! Original value was \"%v\".", dst, src)
+			}
+		}
+	}
+
+	if buf := (DPT_18001{Learn: true, Scene: 5}).Pack(); !bytes.Equal(buf, []byte{0, 0x85}) {
+		t.Errorf("Learning scene 6 packs to %v, expected [0 133].", buf)
+	}
+	if s := (DPT_18001{Scene: 0}).String(); s != "Activate scene 1" {
+		t.Errorf("Wrong label \"%s\" for scene 0, expected \"Activate scene 1\".", s)
+	}
+
+	// Reserved bit 6 is ignored.
+	dst.Unpack([]byte{0, 0x41})
+	if dst != (DPT_18001{Scene: 1}) {
+		t.Errorf("Reserved bit yields \"%v\".", dst)
+	}
+}
+
+// Test DPT 20.102 (HVAC Mode) with known and reserved codes
+func TestDPT_20102(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_20102
+
+	for value := 0; value <= 255; value++ {
+		src = DPT_20102(value)
+		buf = src.Pack()
+		dst.Unpack(buf)
+		if dst != src {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	if s := DPT_20102(1).String(); s != "Comfort" {
+		t.Errorf("Wrong label \"%s\" for code 1, expected \"Comfort\".", s)
+	}
+
+	// Reserved codes must be preserved exactly.
+	if err := dst.Unpack([]byte{0, 42}); err != nil {
+		t.Errorf("Unpacking reserved code failed: %v", err)
+	}
+	if s := dst.String(); s != "unknown(42)" {
+		t.Errorf("Wrong label \"%s\" for reserved code, expected \"unknown(42)\".", s)
+	}
+	if buf = dst.Pack(); buf[1] != 42 {
+		t.Errorf("Reserved code packs to %d, expected 42.", buf[1])
+	}
+}
+
+// Test parsing of DPT 20.102 mode names
+func TestParseHVACMode(t *testing.T) {
+	for mode := DPT_20102(0); mode <= 4; mode++ {
+		for _, name := range []string{mode.String(), strings.ToLower(mode.String()), strings.ToUpper(mode.String())} {
+			parsed, err := ParseHVACMode(name)
+			if err != nil || parsed != mode {
+				t.Errorf("Parsing \"%s\" yields (%v, %v), expected %v.", name, parsed, err, mode)
+			}
+		}
+	}
+
+	for _, name := range []string{"", "Comfy", "unknown(42)"} {
+		if _, err := ParseHVACMode(name); err != ErrUnknownHVACMode {
+			t.Errorf("Parsing \"%s\" yields %v instead of ErrUnknownHVACMode.", name, err)
+		}
+	}
+}
+
+// Test DPT 20.105 (HVAC Control Mode) with values within range
+func TestDPT_20105(t *testing.T) {
+	var dst DPT_20105
+
+	for value := 0; value <= 255; value++ {
+		src := DPT_20105(value)
+		dst.Unpack(src.Pack())
+		if dst != src {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		}
+	}
+
+	labels := []string{
+		"Auto", "Heat", "Morning Warmup", "Cool", "Night Purge", "Precool", "Off", "Test",
+		"Emergency Heat", "Fan Only", "Free Cool", "Ice", "Maximum Heating", "Economic Heat/Cool",
+		"Dehumidification", "Calibration", "Emergency Cool", "Emergency Steam",
+	}
+	for code, label := range labels {
+		if s := DPT_20105(code).String(); s != label {
+			t.Errorf("Wrong label \"%s\" for code %d, expected \"%s\".", s, code, label)
+		}
+	}
+
+	if s := DPT_20105(20).String(); s != "NoDem" {
+		t.Errorf("Wrong label \"%s\" for code 20, expected \"NoDem\".", s)
+	}
+	if s := DPT_20105(18).String(); s != "unknown(18)" {
+		t.Errorf("Wrong label \"%s\" for reserved code, expected \"unknown(18)\".", s)
+	}
+}
 
-	// Positive
-	for i := 1; i <= 10; i++ {
-		value := rand.Int31()
+// Test DPT 20.107 (Changeover Mode) with values within range
+func TestDPT_20107(t *testing.T) {
+	var dst DPT_20107
 
-		src = DPT_13010(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
-		}
-		buf = src.Pack()
-		dst.Unpack(buf)
-		if int32(dst) != value {
+	for value := 0; value <= 255; value++ {
+		src := DPT_20107(value)
+		dst.Unpack(src.Pack())
+		if dst != src {
 			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
 		}
 	}
 
-	// Negative
-	for i := 1; i <= 10; i++ {
-		value := -rand.Int31()
-
-		src = DPT_13010(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
-		}
-		buf = src.Pack()
-		dst.Unpack(buf)
-		if int32(dst) != value {
-			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+	for code, label := range []string{"Auto", "Cooling Only", "Heating Only"} {
+		if s := DPT_20107(code).String(); s != label {
+			t.Errorf("Wrong label \"%s\" for code %d, expected \"%s\".", s, code, label)
 		}
 	}
+
+	if s := DPT_20107(3).String(); s != "unknown(3)" {
+		t.Errorf("Wrong label \"%s\" for reserved code, expected \"unknown(3)\".", s)
+	}
 }
 
-// Test DPT 13.011 (apparant energy)
-func TestDPT_13011(t *testing.T) {
+// Test DPT 28.001 (UTF-8 String) with values within range
+func TestDPT_28001(t *testing.T) {
 	var buf []byte
-	var src, dst DPT_13011
+	var src, dst DPT_28001
 
-	// Corner cases
-	for _, value := range []int32{math.MinInt32, math.MaxInt32} {
-		src = DPT_13011(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
-		}
+	for _, value := range []string{"", "KNX", "Grüße aus Köln", "温度 ☀", "a rather long string exceeding fourteen characters"} {
+		src = DPT_28001(value)
 		buf = src.Pack()
-		dst.Unpack(buf)
-		if int32(dst) != value {
+		if len(buf) != len(value)+2 || buf[0] != 0 || buf[len(buf)-1] != 0 {
+			t.Errorf("Packed value \"%s\" has invalid frame %v.", src, buf)
+		}
+		if err := dst.Unpack(buf); err != nil {
+			t.Errorf("Unpacking value \"%s\" failed: %v", src, err)
+		}
+		if string(dst) != value {
 			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
 		}
 	}
 
-	// Positive
-	for i := 1; i <= 10; i++ {
-		value := rand.Int31()
+	// Packing stops at an embedded null character.
+	if buf = DPT_28001("ab\x00cd").Pack(); len(buf) != 4 {
+		t.Errorf("String with embedded null packs to %v, expected it to be cut at the null.", buf)
+	}
+	if !DPT_28001("ab\x00cd").WouldTruncate() {
+		t.Errorf("String with embedded null does not report truncation.")
+	}
+	if DPT_28001("Grüße").WouldTruncate() {
+		t.Errorf("String without null reports truncation.")
+	}
 
-		src = DPT_13011(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
-		}
-		buf = src.Pack()
-		dst.Unpack(buf)
-		if int32(dst) != value {
-			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
-		}
+	if err := dst.Unpack([]byte{0, 'a', 0, 'b', 0}); err == nil {
+		t.Errorf("Unpacking data with an embedded null should fail.")
+	}
+	if err := dst.Unpack([]byte{0, 'a', 'b'}); err != ErrInvalidLength {
+		t.Errorf("Unpacking unterminated data should fail with ErrInvalidLength, got %v", err)
+	}
+	if err := dst.Unpack([]byte{0, 0xff, 0xfe, 0}); err == nil {
+		t.Errorf("Unpacking invalid UTF-8 should fail.")
 	}
+}
 
-	// Negative
-	for i := 1; i <= 10; i++ {
-		value := -rand.Int31()
+// Test DPT 206.100 (HVAC Mode Next) with values within range
+func TestDPT_206100(t *testing.T) {
+	var buf []byte
+	var src, dst DPT_206100
 
-		src = DPT_13011(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+	for i := 1; i <= 10; i++ {
+		src = DPT_206100{
+			Delay: uint16(rand.Uint32()),
+			Mode:  DPT_20102(rand.Intn(256)),
 		}
 		buf = src.Pack()
+		if len(buf) != 4 {
+			t.Errorf("Packed value \"%s\" has invalid length %d.", src, len(buf))
+		}
 		dst.Unpack(buf)
-		if int32(dst) != value {
-			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		if dst != src {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%s\".", dst, src)
 		}
 	}
+
+	// Switch to standby in 30 minutes
+	if err := dst.Unpack([]byte{0x00, 0x00, 0x1E, 0x02}); err != nil {
+		t.Errorf("Unpacking sample frame failed: %v", err)
+	}
+	if dst.Delay != 30 || dst.Mode != 2 || dst.Duration() != 30*time.Minute {
+		t.Errorf("Sample frame yields \"%s\", expected \"Standby in 30 min\".", dst)
+	}
+
+	if err := dst.Unpack([]byte{0x00, 0x00, 0x1E}); err != ErrInvalidLength {
+		t.Errorf("Unpacking short frame should fail with ErrInvalidLength, got %v", err)
+	}
 }
 
-// Test DPT 13.012 (reactive energy)
-func TestDPT_13012(t *testing.T) {
+// Test DPT 232.600 (Colour RGB) with values within range
+func TestDPT_232600(t *testing.T) {
 	var buf []byte
-	var src, dst DPT_13012
+	var src, dst DPT_232600
 
-	// Corner cases
-	for _, value := range []int32{math.MinInt32, math.MaxInt32} {
-		src = DPT_13012(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+	for i := 1; i <= 10; i++ {
+		src = DPT_232600{
+			Red:   uint8(rand.Uint32()),
+			Green: uint8(rand.Uint32()),
+			Blue:  uint8(rand.Uint32()),
 		}
 		buf = src.Pack()
 		dst.Unpack(buf)
-		if int32(dst) != value {
-			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		if dst != src {
+			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%s\".", dst, src)
 		}
 	}
+}
 
-	// Positive
-	for i := 1; i <= 10; i++ {
-		value := rand.Int31()
+// Test conversion of DPT 232.600 from and to hex colour strings
+func TestDPT_232600Hex(t *testing.T) {
+	cases := []struct {
+		hex      string
+		expected DPT_232600
+	}{
+		{"#FF0000", DPT_232600{255, 0, 0}},
+		{"00ff00", DPT_232600{0, 255, 0}},
+		{"#0a0B0c", DPT_232600{10, 11, 12}},
+	}
 
-		src = DPT_13012(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
-		}
-		buf = src.Pack()
-		dst.Unpack(buf)
-		if int32(dst) != value {
-			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+	for _, c := range cases {
+		value, err := NewDPT_232600FromHex(c.hex)
+		if err != nil {
+			t.Errorf("Parsing \"%s\" failed: %v", c.hex, err)
+		} else if value != c.expected {
+			t.Errorf("Wrong value \"%v\" for \"%s\"! Expected \"%v\".", value, c.hex, c.expected)
 		}
 	}
 
-	// Negative
-	for i := 1; i <= 10; i++ {
-		value := -rand.Int31()
-
-		src = DPT_13012(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+	for _, hex := range []string{"", "#", "#FF00", "#FF00000", "#GG0000", "##FF000"} {
+		if _, err := NewDPT_232600FromHex(hex); err != ErrInvalidHexColor {
+			t.Errorf("Parsing \"%s\" yields %v instead of ErrInvalidHexColor.", hex, err)
 		}
-		buf = src.Pack()
-		dst.Unpack(buf)
-		if int32(dst) != value {
-			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+	}
+
+	for i := 0; i < 100; i++ {
+		src := DPT_232600{uint8(rand.Intn(256)), uint8(rand.Intn(256)), uint8(rand.Intn(256))}
+		dst, err := NewDPT_232600FromHex(src.HexString())
+		if err != nil || dst != src {
+			t.Errorf("Wrong value \"%v\" after hex conversion! Original value was \"%v\".", dst, src)
 		}
 	}
 }
 
-// Test DPT 13.013 (active energy (kWh))
-func TestDPT_13013(t *testing.T) {
+// Test DPT 232.601 (Colour HSV) with values within range
+func TestDPT_232601(t *testing.T) {
 	var buf []byte
-	var src, dst DPT_13013
+	var src, dst DPT_232601
 
-	// Corner cases
-	for _, value := range []int32{math.MinInt32, math.MaxInt32} {
-		src = DPT_13013(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+	for i := 1; i <= 10; i++ {
+		src = DPT_232601{
+			Hue:        rand.Float32() * 360,
+			Saturation: rand.Float32() * 100,
+			Value:      rand.Float32() * 100,
 		}
 		buf = src.Pack()
 		dst.Unpack(buf)
-		if int32(dst) != value {
-			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		if abs(dst.Hue-src.Hue) > 360.0/255+epsilon ||
+			abs(dst.Saturation-src.Saturation) > 100.0/255+epsilon ||
+			abs(dst.Value-src.Value) > 100.0/255+epsilon {
+			t.Errorf("Value \"%s\" after pack/unpack above quantization noise! Original value was \"%s\".", dst, src)
 		}
 	}
 
-	// Positive
-	for i := 1; i <= 10; i++ {
-		value := rand.Int31()
+	// Known encodings, all components are rounded to the nearest octet
+	cases := []struct {
+		value    DPT_232601
+		expected []byte
+	}{
+		{DPT_232601{Hue: 90, Saturation: 50, Value: 20}, []byte{0, 64, 128, 51}},
+		{DPT_232601{Hue: -90, Saturation: 0.19607842, Value: 100}, []byte{0, 191, 1, 255}},
+	}
 
-		src = DPT_13013(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
-		}
-		buf = src.Pack()
-		dst.Unpack(buf)
-		if int32(dst) != value {
-			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+	for _, c := range cases {
+		if buf = c.value.Pack(); !bytes.Equal(buf, c.expected) {
+			t.Errorf("\"%s\" packs to %v, expected %v.", c.value, buf, c.expected)
 		}
 	}
+}
 
-	// Negative
-	for i := 1; i <= 10; i++ {
-		value := -rand.Int31()
+// Test conversion between DPT 232.600 (Colour RGB) and DPT 232.601 (Colour HSV)
+func TestDPT_232601Conversion(t *testing.T) {
+	cases := []struct {
+		rgb DPT_232600
+		hsv DPT_232601
+	}{
+		{DPT_232600{255, 0, 0}, DPT_232601{0, 100, 100}},
+		{DPT_232600{0, 255, 0}, DPT_232601{120, 100, 100}},
+		{DPT_232600{0, 0, 255}, DPT_232601{240, 100, 100}},
+		{DPT_232600{255, 255, 255}, DPT_232601{0, 0, 100}},
+		{DPT_232600{0, 0, 0}, DPT_232601{0, 0, 0}},
+	}
 
-		src = DPT_13013(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+	for _, c := range cases {
+		hsv := c.rgb.HSV()
+		if abs(hsv.Hue-c.hsv.Hue) > epsilon ||
+			abs(hsv.Saturation-c.hsv.Saturation) > epsilon ||
+			abs(hsv.Value-c.hsv.Value) > epsilon {
+			t.Errorf("RGB \"%s\" converts to \"%s\", expected \"%s\".", c.rgb, hsv, c.hsv)
 		}
-		buf = src.Pack()
-		dst.Unpack(buf)
-		if int32(dst) != value {
-			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+
+		if rgb := c.hsv.RGB(); rgb != c.rgb {
+			t.Errorf("HSV \"%s\" converts to \"%s\", expected \"%s\".", c.hsv, rgb, c.rgb)
+		}
+
+		// Primary colours survive the wire encoding exactly.
+		var dst DPT_232601
+		dst.Unpack(c.hsv.Pack())
+		if rgb := dst.RGB(); rgb != c.rgb {
+			t.Errorf("HSV \"%s\" converts to \"%s\" after pack/unpack, expected \"%s\".", c.hsv, rgb, c.rgb)
 		}
 	}
 }
 
-// Test DPT 13.014 (apparant energy (kVAh))
-func TestDPT_13014(t *testing.T) {
+// Test DPT 242.600 (Colour xyY) with values within range
+func TestDPT_242600(t *testing.T) {
 	var buf []byte
-	var src, dst DPT_13014
+	var src, dst DPT_242600
 
-	// Corner cases
-	for _, value := range []int32{math.MinInt32, math.MaxInt32} {
-		src = DPT_13014(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+	for i := 1; i <= 10; i++ {
+		src = DPT_242600{
+			X:               rand.Float32(),
+			Y:               rand.Float32(),
+			Brightness:      rand.Float32() * 100,
+			ColorValid:      i%2 == 0,
+			BrightnessValid: i%3 == 0,
 		}
 		buf = src.Pack()
+		if len(buf) != 7 {
+			t.Errorf("Packed value \"%s\" has invalid length %d.", src, len(buf))
+		}
 		dst.Unpack(buf)
-		if int32(dst) != value {
-			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+		if abs(dst.X-src.X) > 1.0/65535+epsilon ||
+			abs(dst.Y-src.Y) > 1.0/65535+epsilon ||
+			abs(dst.Brightness-src.Brightness) > 100.0/255+epsilon ||
+			dst.ColorValid != src.ColorValid ||
+			dst.BrightnessValid != src.BrightnessValid {
+			t.Errorf("Value \"%s\" after pack/unpack above quantization noise! Original value was \"%s\".", dst, src)
 		}
 	}
 
-	// Positive
-	for i := 1; i <= 10; i++ {
-		value := rand.Int31()
+	if buf = (DPT_242600{Brightness: 50}).Pack(); buf[5] != 128 {
+		t.Errorf("Brightness 50%% packs to %d, expected 128.", buf[5])
+	}
+}
 
-		src = DPT_13014(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
-		}
-		buf = src.Pack()
-		dst.Unpack(buf)
-		if int32(dst) != value {
-			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
-		}
+// Test DPT 242.600 (Colour xyY) gamut validation
+func TestDPT_242600Valid(t *testing.T) {
+	// D65 white point
+	if d := (DPT_242600{X: 0.3127, Y: 0.3290, Brightness: 80}); !d.Valid() {
+		t.Errorf("In-gamut value \"%s\" is reported as invalid.", d)
 	}
 
-	// Negative
-	for i := 1; i <= 10; i++ {
-		value := -rand.Int31()
+	if d := (DPT_242600{X: 0.7, Y: 0.5, Brightness: 80}); d.Valid() {
+		t.Errorf("Out-of-gamut value \"%s\" is reported as valid.", d)
+	}
 
-		src = DPT_13014(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
-		}
-		buf = src.Pack()
-		dst.Unpack(buf)
-		if int32(dst) != value {
-			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
-		}
+	if d := (DPT_242600{X: 0.3127, Y: 0.3290, Brightness: 120}); d.Valid() {
+		t.Errorf("Value \"%s\" with excessive brightness is reported as valid.", d)
 	}
 }
 
-// Test DPT 13.015 (reactive energy (kVARh))
-func TestDPT_13015(t *testing.T) {
+// Test DPT 250.600 (Brightness Colour Temperature Control) with values within range
+func TestDPT_250600(t *testing.T) {
 	var buf []byte
-	var src, dst DPT_13015
+	var src, dst DPT_250600
 
-	// Corner cases
-	for _, value := range []int32{math.MinInt32, math.MaxInt32} {
-		src = DPT_13015(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+	vs := genUint8Slice(0, 7, 1)
+	for _, c := range []bool{true, false} {
+		for _, v := range vs {
+			src = DPT_250600{
+				ColorTempIncrease:  c,
+				ColorTempStep:      v,
+				ColorTempValid:     true,
+				BrightnessIncrease: !c,
+				BrightnessStep:     7 - v,
+				BrightnessValid:    c,
+			}
+			buf = src.Pack()
+			if len(buf) != 4 {
+				t.Errorf("Packed value \"%s\" has invalid length %d.", src, len(buf))
+			}
+			dst.Unpack(buf)
+			if dst != src {
+				t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%s\".", dst, src)
+			}
 		}
-		buf = src.Pack()
-		dst.Unpack(buf)
-		if int32(dst) != value {
-			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+	}
+
+	// Captured frame: colour temperature increase by 3, brightness decrease by 1, both valid
+	if err := dst.Unpack([]byte{0x00, 0x0B, 0x01, 0x03}); err != nil {
+		t.Errorf("Unpacking captured frame failed: %v", err)
+	}
+	expected := DPT_250600{
+		ColorTempIncrease:  true,
+		ColorTempStep:      3,
+		ColorTempValid:     true,
+		BrightnessIncrease: false,
+		BrightnessStep:     1,
+		BrightnessValid:    true,
+	}
+	if dst != expected {
+		t.Errorf("Wrong value \"%s\" for captured frame! Expected \"%s\".", dst, expected)
+	}
+
+	for _, data := range [][]byte{{0x00}, {0x00, 0x0B, 0x01}, {0x00, 0x0B, 0x01, 0x03, 0x00}} {
+		if err := dst.Unpack(data); err != ErrInvalidLength {
+			t.Errorf("Unpacking %d bytes should fail with ErrInvalidLength, got %v", len(data), err)
 		}
 	}
+}
 
-	// Positive
-	for i := 1; i <= 10; i++ {
-		value := rand.Int31()
+// Test construction and decoding of status octets
+func TestStatusByte(t *testing.T) {
+	var src StatusByte
+	src.Set(0, true)
+	src.Set(3, true)
+	src.Set(7, true)
+	src.Set(3, false)
+	src.Set(5, true)
+	src.Set(8, true)
+
+	if src != 0xa1 || src.String() != "10100001" {
+		t.Errorf("Wrong status octet \"%s\", expected \"10100001\".", src)
+	}
+	if buf := src.Pack(); !bytes.Equal(buf, []byte{0, 0xa1}) {
+		t.Errorf("Status octet packs to %v, expected [0 161].", buf)
+	}
 
-		src = DPT_13015(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
+	var dst StatusByte
+	if err := dst.Unpack([]byte{0, 0xa1}); err != nil {
+		t.Errorf("Unpacking status octet failed: %v", err)
+	}
+	for bit, expected := range []bool{true, false, false, false, false, true, false, true} {
+		if dst.Bit(bit) != expected {
+			t.Errorf("Bit %d of \"%s\" is %v, expected %v.", bit, dst, dst.Bit(bit), expected)
 		}
+	}
+	if dst.Bit(-1) || dst.Bit(8) {
+		t.Errorf("Bits outside of the octet are set.")
+	}
+
+	if err := dst.Unpack([]byte{0xa1}); err != ErrInvalidLength {
+		t.Errorf("Unpacking a single octet yields %v instead of ErrInvalidLength.", err)
+	}
+}
+
+// Test Counter24 (3-octet unsigned counter) with values within range
+func TestCounter24(t *testing.T) {
+	var buf []byte
+	var src, dst Counter24
+
+	for _, value := range []uint32{0, 1, 0xffff, 0x10000, 0xffffff, rand.Uint32() & 0xffffff} {
+		src = Counter24(value)
 		buf = src.Pack()
+		if len(buf) != 4 || buf[0] != 0 {
+			t.Errorf("Packed value \"%s\" has invalid frame %v.", src, buf)
+		}
 		dst.Unpack(buf)
-		if int32(dst) != value {
+		if uint32(dst) != value {
 			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
 		}
 	}
 
-	// Negative
-	for i := 1; i <= 10; i++ {
-		value := -rand.Int31()
+	dst.Unpack(Counter24(0x1000000).Pack())
+	if dst != 0xffffff {
+		t.Errorf("Value above 24-bit range yields \"%s\", expected clamping to 16777215.", dst)
+	}
 
-		src = DPT_13015(value)
-		if int32(src) != value {
-			t.Errorf("Assignment of value \"%v\" failed! Has value \"%s\".", value, src)
-		}
-		buf = src.Pack()
-		dst.Unpack(buf)
-		if int32(dst) != value {
-			t.Errorf("Wrong value \"%s\" after pack/unpack! Original value was \"%v\".", dst, value)
+	for _, data := range [][]byte{{0, 1, 2}, {0, 1, 2, 3, 4}} {
+		if err := dst.Unpack(data); err != ErrInvalidLength {
+			t.Errorf("Unpacking %d bytes should fail with ErrInvalidLength, got %v", len(data), err)
 		}
 	}
 }
+
+// Test DPT_Raw (unknown datapoint type)
+func TestDPT_Raw(t *testing.T) {
+	var dst DPT_Raw
+
+	data := []byte{0x00, 0x0C, 0x33, 0xFF, 0x7F}
+	if err := dst.Unpack(data); err != nil {
+		t.Errorf("Unpacking raw data failed: %v", err)
+	}
+
+	data[1] = 0
+	if buf := dst.Pack(); !bytes.Equal(buf, []byte{0x00, 0x0C, 0x33, 0xFF, 0x7F}) {
+		t.Errorf("Raw value packs to %v.", buf)
+	}
+
+	if s := dst.String(); s != "[5] 0x00 0x0C 0x33 0xFF 0x7F" {
+		t.Errorf("Raw value has string \"%s\".", s)
+	}
+
+	if s := DPT_Raw(nil).String(); s != "[0]" {
+		t.Errorf("Empty raw value has string \"%s\".", s)
+	}
+}